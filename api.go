@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/htnosakura/go-reconnectwifi/logging"
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+// startAPIServer 按 addr 的形式启动本地控制API：
+// 以 "unix:" 为前缀时监听Unix域套接字，否则监听TCP地址（例如 "127.0.0.1:8080"）。
+// 服务器在后台goroutine中运行，本函数只负责完成监听并立即返回。
+func startAPIServer(addr string, supervisor *Supervisor, logLevels *logging.ComponentLevels) error {
+	network := "tcp"
+	listenAddr := addr
+	if after, ok := strings.CutPrefix(addr, "unix:"); ok {
+		network = "unix"
+		listenAddr = after
+	}
+
+	listener, err := net.Listen(network, listenAddr)
+	if err != nil {
+		return fmt.Errorf("监听 %s://%s 失败: %w", network, listenAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", handleStatus(supervisor))
+	mux.HandleFunc("GET /scan", handleScan(supervisor))
+	mux.HandleFunc("POST /connect", handleConnect(supervisor))
+	mux.HandleFunc("POST /disconnect", handleDisconnect(supervisor))
+	mux.HandleFunc("GET /events", handleEvents(supervisor))
+	mux.HandleFunc("GET /metrics", handleMetrics(supervisor))
+	mux.HandleFunc("GET /loglevel", handleGetLogLevel(logLevels))
+	mux.HandleFunc("POST /loglevel", handleSetLogLevel(logLevels))
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			withComponent("api").Error("本地控制API异常退出", slog.Any("错误", err))
+		}
+	}()
+	return nil
+}
+
+// writeJSON 将 v 编码为JSON并写入响应，出错时记录日志但不再尝试写状态码（头部可能已发送）。
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		withComponent("api").Error("编码API响应失败", slog.Any("错误", err))
+	}
+}
+
+func handleStatus(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.Status())
+	}
+}
+
+func handleScan(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		networks, err := s.Scan()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		writeJSON(w, networks)
+	}
+}
+
+// connectRequest 是 POST /connect 的请求体。
+type connectRequest struct {
+	SSID     string `json:"ssid"`
+	Password string `json:"password,omitempty"`
+}
+
+func handleConnect(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req connectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("无效的请求体: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.SSID == "" {
+			http.Error(w, "ssid 不能为空", http.StatusBadRequest)
+			return
+		}
+		if err := s.Connect(req.SSID, wifi.Credentials{Password: req.Password}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		writeJSON(w, s.Status())
+	}
+}
+
+func handleDisconnect(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := s.Disconnect(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		writeJSON(w, s.Status())
+	}
+}
+
+// handleEvents 以Server-Sent Events的形式持续推送状态转换事件，直到客户端断开连接。
+func handleEvents(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "当前响应不支持流式推送", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		events, cancel := s.Subscribe()
+		defer cancel()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(evt)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "data: %s\n\n", payload)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// handleMetrics 以 Prometheus 文本格式输出重连统计信息。
+func handleMetrics(s *Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := s.Status()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		fmt.Fprintln(w, "# HELP reconnectwifi_reconnect_count_total 自启动以来完成的连接建立次数")
+		fmt.Fprintln(w, "# TYPE reconnectwifi_reconnect_count_total counter")
+		fmt.Fprintf(w, "reconnectwifi_reconnect_count_total %d\n", status.ReconnectCount)
+
+		fmt.Fprintln(w, "# HELP reconnectwifi_connected 当前接口是否已连接到候选SSID (1=是, 0=否)")
+		fmt.Fprintln(w, "# TYPE reconnectwifi_connected gauge")
+		fmt.Fprintf(w, "reconnectwifi_connected %d\n", boolToInt(status.Connected))
+
+		fmt.Fprintln(w, "# HELP reconnectwifi_connection_uptime_seconds 当前连接已持续的秒数")
+		fmt.Fprintln(w, "# TYPE reconnectwifi_connection_uptime_seconds gauge")
+		fmt.Fprintf(w, "reconnectwifi_connection_uptime_seconds %.3f\n", status.UptimeSeconds)
+
+		fmt.Fprintln(w, "# HELP reconnectwifi_signal_dbm 当前关联信号强度（dBm）")
+		fmt.Fprintln(w, "# TYPE reconnectwifi_signal_dbm gauge")
+		fmt.Fprintf(w, "reconnectwifi_signal_dbm %d\n", status.SignalDBm)
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// logLevelResponse 是 GET 与 POST /loglevel 的响应体；POST 的请求体见 logLevelRequest。
+type logLevelResponse struct {
+	Default   string            `json:"default"`
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// snapshotLogLevel 把 logLevels 的当前状态序列化为 logLevelResponse。
+func snapshotLogLevel(logLevels *logging.ComponentLevels) logLevelResponse {
+	def, overrides := logLevels.Snapshot()
+	resp := logLevelResponse{Default: def.String()}
+	if len(overrides) > 0 {
+		resp.Overrides = make(map[string]string, len(overrides))
+		for component, level := range overrides {
+			resp.Overrides[component] = level.String()
+		}
+	}
+	return resp
+}
+
+// handleGetLogLevel 返回当前生效的默认日志级别及各组件覆盖。
+func handleGetLogLevel(logLevels *logging.ComponentLevels) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, snapshotLogLevel(logLevels))
+	}
+}
+
+// logLevelRequest 是 POST /loglevel 的请求体：spec 的语法与 -loglevel 命令行参数相同，
+// 例如 "warn,scan=debug,connect=info,health=warn"。
+type logLevelRequest struct {
+	Spec string `json:"spec"`
+}
+
+// handleSetLogLevel 热更新日志级别配置，等价于命令行上的 -loglevel，
+// 让用户无需重启进程（也无需等待SIGHUP）即可调整日志级别。
+func handleSetLogLevel(logLevels *logging.ComponentLevels) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req logLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("无效的请求体: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := logLevels.Parse(req.Spec); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		withComponent("api").Info("已通过 /loglevel API 更新日志级别配置", slog.String("规则", req.Spec))
+		writeJSON(w, snapshotLogLevel(logLevels))
+	}
+}
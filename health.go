@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+// healthCheckConfig 聚合了 -health-* 系列命令行参数。
+type healthCheckConfig struct {
+	url     string
+	timeout time.Duration
+	retries int
+}
+
+// healthCheck 对指定接口执行一次完整的连通性验证，依次确认：
+// (1) DHCP分配的IPv4地址不是链路本地地址(APIPA)，(2) 默认网关可达，
+// (3) 可以解析健康检查URL对应的域名，(4) 该URL的HTTP响应状态码正常。
+// 任一探测失败都视为本次验证失败，每一步的结果都以debug级别记录，
+// 便于区分究竟是DHCP失败、网关不可达，还是被强制门户(captive portal)劫持。
+func healthCheck(iface string, cfg healthCheckConfig) error {
+	log := withComponent("health")
+
+	if err := checkDHCP(iface); err != nil {
+		log.Debug("健康检查: DHCP地址探测失败", slog.String("接口", iface), slog.Any("错误", err))
+		return fmt.Errorf("DHCP地址探测失败: %w", err)
+	}
+	log.Debug("健康检查: DHCP地址探测通过", slog.String("接口", iface))
+
+	if err := checkGateway(iface, cfg.timeout); err != nil {
+		log.Debug("健康检查: 默认网关探测失败", slog.String("接口", iface), slog.Any("错误", err))
+		return fmt.Errorf("默认网关探测失败: %w", err)
+	}
+	log.Debug("健康检查: 默认网关探测通过", slog.String("接口", iface))
+
+	host, err := healthCheckHost(cfg.url)
+	if err != nil {
+		return fmt.Errorf("解析 -health-url 失败: %w", err)
+	}
+	if err := checkDNS(host, cfg.timeout); err != nil {
+		log.Debug("健康检查: DNS解析失败", slog.String("域名", host), slog.Any("错误", err))
+		return fmt.Errorf("DNS解析失败: %w", err)
+	}
+	log.Debug("健康检查: DNS解析通过", slog.String("域名", host))
+
+	if err := checkHTTP(cfg.url, cfg.timeout); err != nil {
+		log.Debug("健康检查: HTTP探测失败(可能被强制门户劫持)", slog.String("URL", cfg.url), slog.Any("错误", err))
+		return fmt.Errorf("HTTP探测失败: %w", err)
+	}
+	log.Debug("健康检查: HTTP探测通过", slog.String("URL", cfg.url))
+	return nil
+}
+
+// healthCheckRetryBackoff 是每次重试前的等待时间。connectWithHealthCheck 紧跟在
+// provider.Connect 返回之后立即调用，而OS通常只保证"已接受连接请求"，DHCP完成、
+// 网关可达往往还需要几百毫秒到几秒；不等待的话重试只是在同一个瞬间把失败的
+// checkDHCP 又原样跑了一遍，起不到任何作用。
+const healthCheckRetryBackoff = 2 * time.Second
+
+// healthCheckWithRetries 最多尝试 cfg.retries+1 次 healthCheck，重试之间等待
+// healthCheckRetryBackoff 给DHCP/网关一点完成连接的时间，返回最后一次的错误。
+func healthCheckWithRetries(iface string, cfg healthCheckConfig) error {
+	var lastErr error
+	for attempt := 0; attempt <= cfg.retries; attempt++ {
+		if lastErr = healthCheck(iface, cfg); lastErr == nil {
+			return nil
+		}
+		if attempt < cfg.retries {
+			withComponent("health").Debug("健康检查未通过，准备重试", slog.String("接口", iface), slog.Int("已尝试次数", attempt+1))
+			time.Sleep(healthCheckRetryBackoff)
+		}
+	}
+	return lastErr
+}
+
+// healthCheckHost 从健康检查URL中提取用于DNS探测的主机名。
+func healthCheckHost(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("URL %q 未包含主机名", rawURL)
+	}
+	return u.Hostname(), nil
+}
+
+// checkDHCP 确认接口至少有一个非链路本地(非APIPA)的IPv4地址，
+// 对应 wifiscan 示例中 CheckDHCP 的思路：APIPA地址说明DHCP从未成功过。
+func checkDHCP(iface string) error {
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return fmt.Errorf("查询接口 %s 失败: %w", iface, err)
+	}
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return fmt.Errorf("获取接口 %s 地址失败: %w", iface, err)
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip4 := ipNet.IP.To4()
+		if ip4 == nil || ip4.IsLinkLocalUnicast() {
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("接口 %s 没有可用的IPv4地址（可能DHCP失败，仅持有APIPA/链路本地地址）", iface)
+}
+
+// checkGateway 查询接口的默认网关并对其发起一次ping探测。
+func checkGateway(iface string, timeout time.Duration) error {
+	gw, err := wifi.DefaultGateway(iface)
+	if err != nil {
+		return fmt.Errorf("查询默认网关失败: %w", err)
+	}
+	if err := pingHost(gw.String(), timeout); err != nil {
+		return fmt.Errorf("默认网关 %s 不可达: %w", gw, err)
+	}
+	return nil
+}
+
+// pingHost 调用系统自带的 ping 命令探测目标主机是否可达，参数按操作系统差异调整。
+func pingHost(host string, timeout time.Duration) error {
+	var args []string
+	switch runtime.GOOS {
+	case "windows":
+		args = []string{"-n", "1", "-w", strconv.Itoa(int(timeout.Milliseconds())), host}
+	case "darwin":
+		// BSD ping 的 -W 以毫秒为单位，与Linux不同。
+		args = []string{"-c", "1", "-W", strconv.Itoa(int(timeout.Milliseconds())), host}
+	default: // linux
+		seconds := max(int(timeout.Seconds()), 1)
+		args = []string{"-c", "1", "-W", strconv.Itoa(seconds), host}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+time.Second)
+	defer cancel()
+	if err := exec.CommandContext(ctx, "ping", args...).Run(); err != nil {
+		return fmt.Errorf("ping %s 失败: %w", host, err)
+	}
+	return nil
+}
+
+// checkDNS 解析 host，确认至少能得到一个地址。
+func checkDNS(host string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var resolver net.Resolver
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("域名 %s 未解析出任何地址", host)
+	}
+	return nil
+}
+
+// checkHTTP 对 rawURL 发起一次HTTP GET，并拒绝跟随重定向：强制门户通常会把探测
+// 请求302重定向到登录页，而登录页自身往往以200响应，如果跟随重定向再看最终状态码
+// 就会把门户误判成连通。不跟随重定向后，任何非2xx响应（重定向本身算在内）都视为
+// 探测失败，这也是Android/ChromeOS等系统检测强制门户的标准做法。
+func checkHTTP(rawURL string, timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("意外的HTTP状态码: %d（可能被强制门户重定向或劫持）", resp.StatusCode)
+	}
+	return nil
+}
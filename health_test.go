@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckHTTPRejectsCaptivePortalRedirect(t *testing.T) {
+	portal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK) // 门户登录页本身返回200
+	}))
+	defer portal.Close()
+
+	probe := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, portal.URL, http.StatusFound) // 探测URL被劫持重定向到登录页
+	}))
+	defer probe.Close()
+
+	if err := checkHTTP(probe.URL, time.Second); err == nil {
+		t.Fatalf("checkHTTP() error = nil, want error for a redirected (captive-portal) response")
+	}
+}
+
+func TestCheckHTTPAcceptsDirect2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent) // 例如默认 generate_204 探测URL的真实响应
+	}))
+	defer srv.Close()
+
+	if err := checkHTTP(srv.URL, time.Second); err != nil {
+		t.Fatalf("checkHTTP() error = %v, want nil for a direct 2xx response", err)
+	}
+}
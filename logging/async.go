@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// AsyncWriter 用一个有界channel包装底层io.Writer，把实际写入交给后台goroutine，
+// 使调用方（重连主循环）的日志调用不会被阻塞的文件/网络I/O拖慢。
+// 队列写满时采用丢弃最旧一条的策略（drop-oldest），而不是阻塞调用方或丢弃最新日志，
+// 这样最近发生的事件总能被记录下来，用于排查问题。
+type AsyncWriter struct {
+	next    io.Writer
+	queue   chan []byte
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter 创建一个后台写入 next 的 AsyncWriter，bufferSize 是待写队列的容量。
+func NewAsyncWriter(next io.Writer, bufferSize int) *AsyncWriter {
+	w := &AsyncWriter{
+		next:  next,
+		queue: make(chan []byte, bufferSize),
+		done:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run 是后台写入goroutine，持续消费队列直到 Close 关闭它。
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+	for p := range w.queue {
+		_, _ = w.next.Write(p)
+	}
+}
+
+// Write 实现 io.Writer。p 会被拷贝一份入队，队列已满时丢弃最旧的一条待写日志腾出空间。
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	copy(buf, p)
+	for {
+		select {
+		case w.queue <- buf:
+			return len(p), nil
+		default:
+		}
+		select {
+		case <-w.queue:
+			w.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// Dropped 返回自启动以来因队列已满而被丢弃的日志条数。
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close 关闭队列，等待后台goroutine写完所有已入队的日志，再关闭底层Writer（如果它实现了io.Closer）。
+func (w *AsyncWriter) Close() error {
+	close(w.queue)
+	<-w.done
+	if c, ok := w.next.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// componentHandler 包装一个底层 slog.Handler，按 "component" 属性过滤日志：
+// 每条记录实际生效的级别来自 ComponentLevels，而不是底层Handler自带的
+// 静态级别阈值。底层Handler应以 slog.LevelDebug 构造，把真正的过滤工作
+// 完全交给本Handler，避免两层过滤互相打架。
+type componentHandler struct {
+	next      slog.Handler
+	levels    *ComponentLevels
+	component string // 由 WithAttrs 捕获自最近一次 "component" 属性，初始为空
+}
+
+// NewComponentHandler 用 levels 包装 next，返回按组件分级过滤的 slog.Handler。
+func NewComponentHandler(next slog.Handler, levels *ComponentLevels) slog.Handler {
+	return &componentHandler{next: next, levels: levels}
+}
+
+// Enabled 实现 slog.Handler。由于 logger.With("component", x) 产生的Handler
+// 实例已经在 WithAttrs 中捕获了组件名，这里可以直接按该组件的级别判断，
+// 不需要等到 Handle 阶段才知道记录属于哪个组件。
+func (h *componentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.levels.Level(h.component)
+}
+
+// Handle 实现 slog.Handler。再次检查记录自身携带的 "component" 属性
+// （覆盖通过 With 继承来的组件名），处理直接调用 slog.Info(msg, "component", "x")
+// 而非 logger.With(...) 的场景。记录在到达 Handle 之前已经按继承来的组件名
+// （没有则按默认级别）通过了 Enabled，因此记录自身携带的组件属性只能让过滤更严格，
+// 不能放行比该级别更低的记录。
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	component := h.component
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "component" {
+			if s, ok := a.Value.Any().(string); ok {
+				component = s
+			}
+			return false
+		}
+		return true
+	})
+	if r.Level < h.levels.Level(component) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs 实现 slog.Handler，捕获 "component" 属性供后续 Enabled/Handle 使用。
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+	for _, a := range attrs {
+		if a.Key == "component" {
+			if s, ok := a.Value.Any().(string); ok {
+				component = s
+			}
+		}
+	}
+	return &componentHandler{next: h.next.WithAttrs(attrs), levels: h.levels, component: component}
+}
+
+// WithGroup 实现 slog.Handler。
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{next: h.next.WithGroup(name), levels: h.levels, component: h.component}
+}
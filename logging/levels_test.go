@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestComponentLevelsParse(t *testing.T) {
+	c := NewComponentLevels(slog.LevelInfo)
+	if err := c.Parse("warn,scan=debug,health=error"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := c.Level("scan"); got != slog.LevelDebug {
+		t.Errorf("Level(scan) = %v, want Debug", got)
+	}
+	if got := c.Level("health"); got != slog.LevelError {
+		t.Errorf("Level(health) = %v, want Error", got)
+	}
+	if got := c.Level("connect"); got != slog.LevelWarn {
+		t.Errorf("Level(connect) = %v, want Warn (default)", got)
+	}
+	if got := c.Level(""); got != slog.LevelWarn {
+		t.Errorf("Level(\"\") = %v, want Warn (default)", got)
+	}
+}
+
+func TestComponentLevelsParseReplacesOverrides(t *testing.T) {
+	c := NewComponentLevels(slog.LevelInfo)
+	if err := c.Parse("scan=debug"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := c.Parse("connect=error"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := c.Level("scan"); got != slog.LevelInfo {
+		t.Errorf("Level(scan) = %v, want Info (override table should be replaced, not merged)", got)
+	}
+	if got := c.Level("connect"); got != slog.LevelError {
+		t.Errorf("Level(connect) = %v, want Error", got)
+	}
+}
+
+func TestComponentLevelsParseInvalid(t *testing.T) {
+	c := NewComponentLevels(slog.LevelInfo)
+	cases := []string{"bogus", "scan=bogus", "=debug"}
+	for _, spec := range cases {
+		if err := c.Parse(spec); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", spec)
+		}
+	}
+	// 解析失败不应修改已有状态。
+	if got := c.Level(""); got != slog.LevelInfo {
+		t.Errorf("Level(\"\") after failed Parse = %v, want unchanged Info", got)
+	}
+}
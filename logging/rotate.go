@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RotatingWriter 是一个支持按大小和按时间切割的日志文件io.Writer，
+// 原生实现（不依赖第三方库）。切割后的归档文件命名为
+// "<原路径>.<切割时间戳>"，按 MaxBackups/MaxAge 两个维度清理旧归档。
+type RotatingWriter struct {
+	path       string
+	maxSize    int64         // 单个日志文件的大小上限（字节），<=0 表示不按大小切割
+	maxAge     time.Duration // 单个日志文件的最长存活时间，<=0 表示不按时间切割
+	maxBackups int           // 保留的归档文件数量上限，<=0 表示不限制
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingWriter 打开（或创建）path 处的日志文件，返回一个准备好接受
+// 写入的 RotatingWriter。
+func NewRotatingWriter(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingWriter, error) {
+	w := &RotatingWriter{path: path, maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// openCurrent 打开（或续写）当前日志文件，并记录其已有大小；按时间切割的起算点
+// 取文件的最后修改时间（续写已有文件时即上次写入的时间，而非文件创建时间）。
+func (w *RotatingWriter) openCurrent() error {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return fmt.Errorf("打开日志文件 %s 失败: %w", w.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("获取日志文件 %s 信息失败: %w", w.path, err)
+	}
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = info.ModTime()
+	return nil
+}
+
+// Write 实现 io.Writer，写入前按需触发一次切割。
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// shouldRotate 判断写入 nextWrite 字节之前是否应当先切割当前文件。
+func (w *RotatingWriter) shouldRotate(nextWrite int64) bool {
+	if w.maxSize > 0 && w.size+nextWrite > w.maxSize {
+		return true
+	}
+	if w.maxAge > 0 && time.Since(w.openedAt) >= w.maxAge {
+		return true
+	}
+	return false
+}
+
+// rotate 关闭当前文件、把它重命名为带时间戳的归档文件，清理旧归档后重新打开。
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("关闭待切割的日志文件 %s 失败: %w", w.path, err)
+	}
+	backupPath := fmt.Sprintf("%s.%s", w.path, time.Now().Format("20060102-150405.000"))
+	if err := os.Rename(w.path, backupPath); err != nil {
+		return fmt.Errorf("归档日志文件 %s 失败: %w", w.path, err)
+	}
+	w.pruneBackups()
+	return w.openCurrent()
+}
+
+// pruneBackups 清理超出 MaxAge/MaxBackups 限制的归档文件。
+// 清理过程中的错误不会中断日志写入，只是那次清理被跳过。
+func (w *RotatingWriter) pruneBackups() {
+	matches, err := filepath.Glob(w.path + ".*")
+	if err != nil {
+		return
+	}
+	sort.Strings(matches) // 时间戳后缀保证字典序即为时间顺序
+
+	now := time.Now()
+	kept := matches[:0]
+	for _, m := range matches {
+		if w.maxAge > 0 {
+			if info, err := os.Stat(m); err == nil && now.Sub(info.ModTime()) > w.maxAge {
+				os.Remove(m)
+				continue
+			}
+		}
+		kept = append(kept, m)
+	}
+	if w.maxBackups > 0 && len(kept) > w.maxBackups {
+		for _, m := range kept[:len(kept)-w.maxBackups] {
+			os.Remove(m)
+		}
+	}
+}
+
+// Close 实现 io.Closer。
+func (w *RotatingWriter) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotatingWriterRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingWriter(path, 10, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write([]byte("more")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("len(backups) = %d, want 1", len(matches))
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(current) error = %v", err)
+	}
+	if string(current) != "more" {
+		t.Errorf("current file content = %q, want %q", current, "more")
+	}
+}
+
+func TestRotatingWriterPrunesByMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingWriter(path, 1, 0, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte("xx")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		time.Sleep(5 * time.Millisecond) // 确保每次归档的时间戳后缀不同
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) > 2 {
+		t.Errorf("len(backups) = %d, want <= 2 (MaxBackups)", len(matches))
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package logging
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// NewSystemLogWriter 返回一个写入本地系统日志设施的io.Writer，供与stdout/文件
+// 组合进 io.MultiWriter 实现多路输出(fan-out)。Unix系统上使用标准的syslog。
+func NewSystemLogWriter(tag string) (io.Writer, error) {
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package logging
+
+import (
+	"io"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// eventLogWriter 把写入适配成一次 Windows 事件日志的 Info 记录。
+type eventLogWriter struct {
+	log *eventlog.Log
+}
+
+// NewSystemLogWriter 返回一个写入 Windows 事件日志的io.Writer，供与stdout/文件
+// 组合进 io.MultiWriter 实现多路输出(fan-out)。source 是事件源名称，
+// 首次使用时会尝试以 InstallAsEventCreate 自注册该事件源。
+func NewSystemLogWriter(source string) (io.Writer, error) {
+	_ = eventlog.InstallAsEventCreate(source, eventlog.Info|eventlog.Warning|eventlog.Error)
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLogWriter{log: l}, nil
+}
+
+// Write 实现 io.Writer。
+func (w *eventLogWriter) Write(p []byte) (int, error) {
+	if err := w.log.Info(1, string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -1,324 +1,230 @@
 package main
 
 import (
-	"bytes"
-	"context"
-	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
-	"os/exec"
+	"os/signal"
 	"strings"
-	"syscall"
 	"time"
-)
 
-// commandTimeout 定义了 netsh 命令执行的默认超时时间。
-const commandTimeout = 10 * time.Second
+	"github.com/htnosakura/go-reconnectwifi/logging"
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
 
 // globalLogger 是全局的 slog 日志记录器。
 var globalLogger *slog.Logger
 
-// --- Custom flag type for slog.Level ---
-type logLevelValue struct {
-	levelVar *slog.LevelVar
+// withComponent 返回一个打了 "component" 标签的子Logger，供 logging.NewComponentHandler
+// 按组件分级过滤。必须在每次需要记录日志时调用（而不是缓存为包级变量），
+// 因为它读取的是调用时刻的 slog.Default()，在 setupSlog 替换默认Logger之前
+// 调用会绑定到尚未完成配置的早期Logger上。
+func withComponent(component string) *slog.Logger {
+	return slog.With("component", component)
 }
 
-// String is part of the flag.Value interface.
-func (v *logLevelValue) String() string {
-	if v.levelVar == nil {
-		return slog.LevelInfo.String() // Default if not set
-	}
-	return v.levelVar.Level().String()
+// globalLogLevels 是当前生效的按组件日志级别配置，由 setupSlog 初始化，
+// 并在 SIGHUP 或 POST /loglevel 时原地更新（无需重启进程、无需替换Handler）。
+var globalLogLevels *logging.ComponentLevels
+
+// --- Custom flag type：-loglevel 接受 "warn,scan=debug,connect=info,health=warn" 形式 ---
+type logLevelValue struct {
+	levels *logging.ComponentLevels
 }
 
-// Set is part of the flag.Value interface.
-// It parses the string and sets the slog.Level.
-func (v *logLevelValue) Set(s string) error {
-	var level slog.Level
-	switch strings.ToLower(s) {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn", "warning":
-		level = slog.LevelWarn
-	case "error", "err":
-		level = slog.LevelError
-	default:
-		return fmt.Errorf("invalid log level: %q (must be debug, info, warn, or error)", s)
+// String 实现 flag.Value。
+func (v *logLevelValue) String() string {
+	if v.levels == nil {
+		return slog.LevelInfo.String()
 	}
-	if v.levelVar == nil {
-		v.levelVar = new(slog.LevelVar) // Ensure it's initialized
+	def, overrides := v.levels.Snapshot()
+	parts := []string{def.String()}
+	for component, level := range overrides {
+		parts = append(parts, component+"="+level.String())
 	}
-	v.levelVar.Set(level)
-	return nil
+	return strings.Join(parts, ",")
 }
 
-// Get returns the current slog.Level.
-func (v *logLevelValue) Get() slog.Level {
-	if v.levelVar == nil {
-		return slog.LevelInfo // Default if somehow not set
+// Set 实现 flag.Value，委托给 logging.ComponentLevels.Parse。
+func (v *logLevelValue) Set(s string) error {
+	if v.levels == nil {
+		v.levels = logging.NewComponentLevels(slog.LevelInfo)
 	}
-	return v.levelVar.Level()
+	return v.levels.Parse(s)
 }
 
-// newLogLevelValue creates a new logLevelValue with a default level.
+// newLogLevelValue 创建一个默认级别为 defaultLevel 的 logLevelValue。
 func newLogLevelValue(defaultLevel slog.Level) *logLevelValue {
-	lv := new(slog.LevelVar)
-	lv.Set(defaultLevel)
-	return &logLevelValue{levelVar: lv}
+	return &logLevelValue{levels: logging.NewComponentLevels(defaultLevel)}
 }
 
 // --- End custom flag type ---
 
-// setupSlog initializes the globalLogger with the specified settings.
-// 日志可以输出到文件或标准输出。
-func setupSlog(logFilePath string, logLevel slog.Level) {
-	var output io.Writer = os.Stdout // 默认输出到标准输出
+// logConfig 聚合了 -logfile/-log-* 系列参数，描述日志子系统应当如何组装。
+type logConfig struct {
+	filePath   string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	format     string // "text" 或 "json"
+	sinks      string // 逗号分隔，留空时按是否设置 filePath 自动选择 file 或 stdout
+	levels     *logging.ComponentLevels
+}
 
-	logHandlerOptions := &slog.HandlerOptions{
-		Level: logLevel,
+// setupSlog 按 cfg 组装 globalLogger：选择输出sink（stdout/file/syslog的任意组合），
+// 文件sink经过原生实现的大小/时间双维度切割与异步有界队列包装，
+// 再套上按组件分级过滤的Handler，最终设置为slog默认记录器。
+func setupSlog(cfg logConfig) error {
+	sinks := strings.Split(cfg.sinks, ",")
+	if cfg.sinks == "" {
+		// 保持历史行为：未显式指定 -log-sinks 时，-logfile 非空则只写文件，否则只写标准输出。
+		if cfg.filePath != "" {
+			sinks = []string{"file"}
+		} else {
+			sinks = []string{"stdout"}
+		}
+	}
+
+	var writers []io.Writer
+	for _, sink := range sinks {
+		switch strings.TrimSpace(sink) {
+		case "stdout":
+			writers = append(writers, os.Stdout)
+			fmt.Println("日志将输出到标准输出。")
+		case "file":
+			if cfg.filePath == "" {
+				return fmt.Errorf("sink %q 要求通过 -logfile 指定文件路径", sink)
+			}
+			rotating, err := logging.NewRotatingWriter(cfg.filePath, cfg.maxSize, cfg.maxAge, cfg.maxBackups)
+			if err != nil {
+				return fmt.Errorf("初始化日志文件切割失败: %w", err)
+			}
+			writers = append(writers, logging.NewAsyncWriter(rotating, 1024))
+			fmt.Printf("日志将写入到: %s (切割: 大小<=%d字节, 保留<=%s, 最多%d份归档)\n",
+				cfg.filePath, cfg.maxSize, cfg.maxAge, cfg.maxBackups)
+		case "syslog":
+			sysWriter, err := logging.NewSystemLogWriter("reconnectwifi")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "初始化系统日志(syslog/Windows事件日志)输出失败，已跳过: %v\n", err)
+				continue
+			}
+			writers = append(writers, sysWriter)
+		default:
+			return fmt.Errorf("无效的 -log-sinks 取值: %q (可选 stdout, file, syslog)", sink)
+		}
+	}
+
+	handlerOptions := &slog.HandlerOptions{
+		// 真正的级别过滤交给 logging.componentHandler，这里放到最低以保证记录能到达它。
+		Level: slog.LevelDebug,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			if a.Key == slog.TimeKey {
-				// 自定义时间格式
 				a.Value = slog.StringValue(a.Value.Time().Format("2006-01-02 15:04:05.000"))
 			}
 			return a
 		},
 	}
 
-	if logFilePath != "" {
-		file, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
-			// 如果日志文件打开失败，则回退到标准输出并记录错误
-			// 在这种早期阶段，globalLogger可能尚未完全初始化，所以直接使用一个新的slog实例打印到stdout
-			earlyLogger := slog.New(slog.NewTextHandler(os.Stdout, logHandlerOptions))
-			earlyLogger.Error("无法打开或创建日志文件，日志将输出到标准输出", slog.String("路径", logFilePath), slog.Any("错误", err))
-			// 保持 output 为 os.Stdout
-		} else {
-			// 如果需要同时输出到文件和控制台，可以使用 io.MultiWriter
-			// output = io.MultiWriter(os.Stdout, file)
-			output = file
-			fmt.Printf("日志将写入到: %s\n", logFilePath) // 初始时仍在控制台打印一条提示
-		}
+	output := io.MultiWriter(writers...)
+	var base slog.Handler
+	if cfg.format == "json" {
+		base = slog.NewJSONHandler(output, handlerOptions)
 	} else {
-		fmt.Println("日志将输出到标准输出。")
+		base = slog.NewTextHandler(output, handlerOptions)
 	}
 
-	globalLogger = slog.New(slog.NewTextHandler(output, logHandlerOptions))
-	slog.SetDefault(globalLogger) // 设置为默认记录器，方便全局使用 slog.Info 等
+	globalLogLevels = cfg.levels
+	globalLogger = slog.New(logging.NewComponentHandler(base, cfg.levels))
+	slog.SetDefault(globalLogger)
 	globalLogger.Info("日志记录器初始化完成")
+	return nil
 }
 
-// runNetshCommand 执行 netsh 命令并返回 stdout, stderr 和错误。
-// 它包含超时和隐藏窗口的逻辑。
-func runNetshCommand(timeout time.Duration, args ...string) (stdout string, stderr string, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "netsh", args...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true} // 隐藏命令执行时弹出的控制台窗口
-
-	var outBuf, errBuf bytes.Buffer
-	cmd.Stdout = &outBuf
-	cmd.Stderr = &errBuf
-
-	err = cmd.Run()
-	stdout = outBuf.String()
-	stderr = errBuf.String()
-
-	if ctx.Err() == context.DeadlineExceeded {
-		return stdout, stderr, fmt.Errorf("命令 '%s' 执行超时 (%v)", strings.Join(args, " "), timeout)
-	}
-	if err != nil {
-		return stdout, stderr, fmt.Errorf("命令 '%s' 执行失败: %w, stderr: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr))
-	}
-	return stdout, stderr, nil
-}
-
-// getWlanInterface尝试自动检测系统上的第一个无线网络接口名称。
-// 这对于用户未明确指定接口名称时非常有用。
-func getWlanInterface() (string, error) {
-	slog.Debug("开始自动检测无线网络接口...")
-	stdout, _, err := runNetshCommand(commandTimeout, "wlan", "show", "interfaces")
-	if err != nil {
-		return "", fmt.Errorf("执行 'netsh wlan show interfaces' 失败 (getWlanInterface): %w", err)
-	}
-
-	lines := strings.SplitSeq(stdout, "\n")
-	for line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		// "名称" 来自用户提供的成功运行的程序中的关键字
-		if strings.HasPrefix(trimmedLine, "名称") || strings.HasPrefix(trimmedLine, "Name") {
-			parts := strings.SplitN(trimmedLine, ":", 2)
-			if len(parts) == 2 {
-				ifaceName := strings.TrimSpace(parts[1])
-				if ifaceName != "" {
-					slog.Info("自动检测到无线网络接口", slog.String("接口名称", ifaceName))
-					return ifaceName, nil
-				}
-			}
-		}
-	}
-	return "", errors.New("未能通过 'netsh wlan show interfaces' 自动检测到无线网络接口 (getWlanInterface)")
-}
-
-// isConnected 检查指定的无线接口是否已连接到目标SSID。
-// 它解析 'netsh wlan show interfaces' 的输出。
-func isConnected(targetSSID string, interfaceName string) (bool, error) {
-	slog.Debug("检查连接状态...", slog.String("目标SSID", targetSSID), slog.String("接口", interfaceName))
-	stdout, stderr, err := runNetshCommand(commandTimeout, "wlan", "show", "interfaces")
-	if err != nil {
-		// 特殊处理WLAN服务未运行或适配器问题
-		errMsg := stderr
-		if errMsg == "" { // exec.ExitError 可能没有 stderr 输出
-			var exitErr *exec.ExitError
-			if errors.As(err, &exitErr) {
-				errMsg = fmt.Sprintf("netsh 命令返回退出状态 %d (可能是WLAN AutoConfig服务未运行, 或Wi-Fi适配器被禁用/不存在)", exitErr.ExitCode())
-			}
-		}
-		return false, fmt.Errorf("执行 'netsh wlan show interfaces' 失败 (isConnected): %w, stderr: %s", err, errMsg)
+// watchLogLevelReload 监听 reloadSignal（Unix上为SIGHUP，Windows上禁用），
+// 收到信号时重新读取 loglevelFilePath 中的级别配置并热更新 globalLogLevels，
+// 使用户无需重启进程即可调整日志级别（Windows上等价的操作是 POST /loglevel）。
+func watchLogLevelReload(loglevelFilePath string) {
+	if reloadSignal == nil {
+		return
 	}
-
-	lines := strings.Split(stdout, "\n")
-	inTargetInterfaceBlock := false
-	var blockParsedSSID string
-	var blockParsedState string // e.g., "已连接", "断开连接"
-
-	// 关键字来自用户提供的成功运行的程序
-	const keywordInterfaceName = "名称"   // "名称"
-	const keywordSSID = "SSID"          // "SSID"
-	const keywordState = "状态"           // "状态"
-	const keywordConnectedState = "已连接" // "已连接"
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		if strings.HasPrefix(trimmedLine, keywordInterfaceName) && strings.Contains(trimmedLine, ":") {
-			parts := strings.SplitN(trimmedLine, ":", 2)
-			if len(parts) == 2 {
-				currentInterfaceNameInOutput := strings.TrimSpace(parts[1])
-				if currentInterfaceNameInOutput == interfaceName {
-					inTargetInterfaceBlock = true
-					blockParsedSSID = "" // 重置当前块的解析状态
-					blockParsedState = ""
-				} else {
-					// 如果我们正在解析一个接口块，并且遇到了一个新的接口块声明，
-					// 而我们之前解析的块不是目标接口块，则将 inTargetInterfaceBlock 设置为 false。
-					// 如果已经是目标接口块，则不应改变，继续解析。
-					if inTargetInterfaceBlock {
-						// 已处理完目标接口块，可以提前判断
-						// (或者如果希望只处理第一个匹配的接口块，这里可以break或返回)
-					}
-					inTargetInterfaceBlock = false
-				}
-			}
-			continue // 继续下一行，避免在同一行处理接口名称和其他属性
-		}
-
-		if inTargetInterfaceBlock {
-			// SSID 行不应包含 "AP BSSID" 来避免混淆
-			if strings.HasPrefix(trimmedLine, keywordSSID) && !strings.Contains(trimmedLine, "AP BSSID") && strings.Contains(trimmedLine, ":") {
-				parts := strings.SplitN(trimmedLine, ":", 2)
-				if len(parts) == 2 {
-					blockParsedSSID = strings.TrimSpace(parts[1])
-				}
-			} else if strings.HasPrefix(trimmedLine, keywordState) && strings.Contains(trimmedLine, ":") {
-				parts := strings.SplitN(trimmedLine, ":", 2)
-				if len(parts) == 2 {
-					blockParsedState = strings.TrimSpace(parts[1])
-				}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, reloadSignal)
+	go func() {
+		for range sigCh {
+			if loglevelFilePath == "" {
+				slog.Info("收到重载信号，但未配置 -loglevel-file，日志级别保持不变")
+				continue
 			}
-
-			// 当SSID和状态都解析出来后，进行判断
-			if blockParsedSSID != "" && blockParsedState != "" {
-				if blockParsedSSID == targetSSID && blockParsedState == keywordConnectedState {
-					slog.Debug("目标SSID已连接", slog.String("SSID", blockParsedSSID), slog.String("状态", blockParsedState))
-					return true, nil
-				}
-				// 如果SSID匹配但状态不是"已连接"，则说明未连接到目标 (或者连接了但状态不对)
-				if blockParsedSSID == targetSSID && blockParsedState != keywordConnectedState {
-					slog.Debug("目标SSID存在但未连接或状态异常", slog.String("SSID", blockParsedSSID), slog.String("状态", blockParsedState))
-					return false, nil // 明确未连接到目标
-				}
+			if err := reloadLogLevelFileInto(globalLogLevels, loglevelFilePath); err != nil {
+				slog.Error("重新加载 -loglevel-file 失败", slog.Any("错误", err))
+				continue
 			}
+			slog.Info("已根据 -loglevel-file 重新加载日志级别配置", slog.String("文件", loglevelFilePath))
 		}
-	}
-	slog.Debug("遍历完接口信息，未确认连接到目标SSID")
-	return false, nil // 遍历完成，未找到匹配且连接的SSID
+	}()
 }
 
-// isNetworkAvailable 检查目标SSID是否存在于指定接口的可见网络列表中。
-func isNetworkAvailable(targetSSID string, interfaceName string) (bool, error) {
-	slog.Debug("检查网络是否可见...", slog.String("目标SSID", targetSSID), slog.String("接口", interfaceName))
-	stdout, stderr, err := runNetshCommand(commandTimeout, "wlan", "show", "networks", fmt.Sprintf("interface=%q", interfaceName), "mode=bssid")
+// reloadLogLevelFileInto 读取 path 并用其内容更新 levels，供启动时加载与
+// SIGHUP/文件重载两种场景共用。
+func reloadLogLevelFileInto(levels *logging.ComponentLevels, path string) error {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		if strings.Contains(stderr, "没有无线网络可见") || strings.Contains(stderr, "No wireless networks are currently visible") {
-			slog.Info("指定接口上没有可见的无线网络", slog.String("接口", interfaceName))
-			return false, nil
-		}
-		return false, fmt.Errorf("执行 'netsh wlan show networks' 失败 (isNetworkAvailable): %w", err)
+		return fmt.Errorf("读取 %s 失败: %w", path, err)
 	}
-
-	lines := strings.Split(stdout, "\n")
-	const keywordSSID = "SSID "
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmedLine, keywordSSID) {
-			parts := strings.SplitN(trimmedLine, ":", 2)
-			if len(parts) == 2 {
-				scannedSSID := strings.TrimSpace(parts[1])
-				slog.Debug("扫描到可见SSID", slog.String("可见SSID", scannedSSID))
-				if scannedSSID == targetSSID {
-					slog.Info("目标SSID可见", slog.String("SSID", targetSSID), slog.String("接口", interfaceName))
-					return true, nil
-				}
-			}
-		}
-	}
-
-	slog.Info("目标SSID在扫描的网络中不可见", slog.String("SSID", targetSSID), slog.String("接口", interfaceName))
-	return false, nil
-}
-
-// connectToWifi 尝试将指定的无线接口连接到目标SSID。
-func connectToWifi(targetSSID string, interfaceName string) error {
-	slog.Info("尝试连接到WiFi...", slog.String("SSID", targetSSID), slog.String("接口", interfaceName))
-	_, stderr, err := runNetshCommand(commandTimeout*2, // 连接操作可能需要更长时间
-		"wlan", "connect", fmt.Sprintf("name=%q", targetSSID), fmt.Sprintf("interface=%q", interfaceName))
-
-	if err != nil {
-		slog.Error("连接命令发送失败", slog.String("SSID", targetSSID), slog.Any("错误", err), slog.String("stderr", stderr))
-		return fmt.Errorf("netsh wlan connect 命令失败: %w", err)
-	}
-	slog.Info("连接命令已成功发送", slog.String("SSID", targetSSID))
-	return nil
+	return levels.Parse(strings.TrimSpace(string(content)))
 }
 
 func main() {
 	// --- 定义命令行参数 ---
-	targetSSIDFlag := flag.String("ssid", "", "要连接的WiFi SSID (必需)")
+	targetSSIDFlag := flag.String("ssid", "", "要连接的WiFi SSID，按优先级排列，用逗号分隔，每项可附带一个用冒号分隔的最小RSSI(dBm)，例如 \"HomeMain:-65,HomeGuest:-70,Mobile\" (必需)")
 	wifiInterfaceFlag := flag.String("interface", "", "无线网络接口名称 (例如 WLAN, Wi-Fi)。如果为空，则尝试自动检测。")
 	checkIntervalFlag := flag.Duration("interval", 15*time.Second, "检查WiFi连接状态的时间间隔 (例如: 10s, 1m)")
+	roamRSSIDropFlag := flag.Int("roam-rssi-drop", 15, "漫游迟滞阈值(dB)：最优候选与当前连接优先级相同时，当前连接信号比它至少弱这么多dB才触发漫游重连；可见更高优先级的候选时不受此阈值限制，直接漫游")
+	apiAddrFlag := flag.String("api-addr", "", "本地控制API监听地址。形如 \"127.0.0.1:8080\" 使用TCP，\"unix:/path/to.sock\" 使用Unix域套接字。为空则不启动API。")
+	healthURLFlag := flag.String("health-url", "http://connectivitycheck.gstatic.com/generate_204", "连接成功后用于验证真实连通性的探测URL，同时用于DNS解析探测。")
+	healthTimeoutFlag := flag.Duration("health-timeout", 5*time.Second, "单次健康检查探测（网关ping/DNS解析/HTTP请求）的超时时间")
+	healthRetriesFlag := flag.Int("health-retries", 2, "健康检查失败后的重试次数（不含首次尝试），超过后放弃该候选并尝试下一个")
 	logFilePathFlag := flag.String("logfile", "", "日志文件路径。如果为空，则输出到标准输出。")
+	logMaxSizeFlag := flag.Int64("log-max-size", 100*1024*1024, "日志文件按大小切割的阈值(字节)，<=0 表示不按大小切割")
+	logMaxAgeFlag := flag.Duration("log-max-age", 7*24*time.Hour, "日志归档文件的最长保留时间，<=0 表示不按时间清理")
+	logMaxBackupsFlag := flag.Int("log-max-backups", 5, "保留的日志归档文件数量上限，<=0 表示不限制")
+	logFormatFlag := flag.String("log-format", "text", "日志输出格式：text 或 json（机器可读，便于日志采集系统解析）")
+	logSinksFlag := flag.String("log-sinks", "", "日志输出目标，逗号分隔，可选 stdout,file,syslog 任意组合（syslog 在Windows上对应事件日志）。留空时保持历史行为：指定了 -logfile 则仅写入文件，否则仅输出到标准输出。")
+	loglevelFileFlag := flag.String("loglevel-file", "", "可选的日志级别配置文件路径，内容格式与 -loglevel 相同。设置后，启动时会用其内容覆盖 -loglevel，并在收到重载信号(Unix上为SIGHUP)时重新读取，从而无需重启即可调整日志级别。")
 
-	// 使用自定义的 logLevelValue 类型
-	logLevelFlag := newLogLevelValue(slog.LevelInfo)                      // 默认设置为 Info
-	flag.Var(logLevelFlag, "loglevel", "日志级别 (debug, info, warn, error)") // 正确的行
+	// 使用自定义的 logLevelValue 类型，支持 "warn,scan=debug,connect=info,health=warn" 这样的按组件覆盖
+	logLevelFlag := newLogLevelValue(slog.LevelInfo)
+	flag.Var(logLevelFlag, "loglevel", "日志级别，支持按组件覆盖，例如 \"warn,scan=debug,connect=info,health=warn\"")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "用法: %s -ssid <目标SSID> [选项]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "用法: %s -ssid <候选SSID列表> [选项]\n", os.Args[0])
 		fmt.Fprintln(os.Stderr, "选项:")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
 
 	// --- 初始化日志 ---
-	setupSlog(*logFilePathFlag, logLevelFlag.Get()) // 使用 Get() 获取 slog.Level
+	if *loglevelFileFlag != "" {
+		if err := reloadLogLevelFileInto(logLevelFlag.levels, *loglevelFileFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "读取 -loglevel-file 失败，继续使用 -loglevel: %v\n", err)
+		}
+	}
+	logCfg := logConfig{
+		filePath:   *logFilePathFlag,
+		maxSize:    *logMaxSizeFlag,
+		maxAge:     *logMaxAgeFlag,
+		maxBackups: *logMaxBackupsFlag,
+		format:     *logFormatFlag,
+		sinks:      *logSinksFlag,
+		levels:     logLevelFlag.levels,
+	}
+	if err := setupSlog(logCfg); err != nil {
+		fmt.Fprintf(os.Stderr, "初始化日志子系统失败: %v\n", err)
+		os.Exit(1)
+	}
+	watchLogLevelReload(*loglevelFileFlag)
 
 	// --- 参数校验 ---
 	if *targetSSIDFlag == "" {
@@ -327,69 +233,106 @@ func main() {
 		os.Exit(1)
 	}
 
+	candidates, err := parseSSIDList(*targetSSIDFlag)
+	if err != nil {
+		slog.Error("解析 -ssid 候选列表失败", slog.Any("错误", err))
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	slog.Info("WiFi自动重连程序启动",
-		slog.String("目标SSID", *targetSSIDFlag),
+		slog.String("候选SSID列表", *targetSSIDFlag),
 		slog.String("指定接口", *wifiInterfaceFlag),
 		slog.Duration("检查间隔", *checkIntervalFlag),
-		slog.String("日志级别", logLevelFlag.Get().String()),
+		slog.Int("漫游迟滞阈值(dB)", *roamRSSIDropFlag),
+		slog.String("日志级别", logLevelFlag.String()),
 	)
 
+	// --- 探测可用的WiFi后端 ---
+	provider, err := wifi.AutoProbe()
+	if err != nil {
+		slog.Error("未能探测到可用的WiFi后端", slog.Any("错误", err))
+		os.Exit(1)
+	}
+	slog.Info("已选择WiFi后端", slog.String("后端", provider.Name()))
+
 	effectiveIfaceName := *wifiInterfaceFlag
 	if effectiveIfaceName == "" {
 		slog.Info("未指定网络接口名称，尝试自动检测...")
-		var err error
-		effectiveIfaceName, err = getWlanInterface()
-		if err != nil {
+		ifaces, err := provider.Interfaces()
+		if err != nil || len(ifaces) == 0 {
 			slog.Error("无法自动检测无线网络接口，请使用 -interface 参数指定。", slog.Any("错误", err))
 			os.Exit(1)
 		}
+		effectiveIfaceName = ifaces[0]
 	}
 	slog.Info("将使用网络接口", slog.String("接口名称", effectiveIfaceName))
 
+	// --- 订阅操作系统网络状态通知（如果后端支持） ---
+	var eventsCh <-chan wifi.Event
+	if source, ok := provider.(wifi.NotificationSource); ok {
+		watcher, err := source.Watch(effectiveIfaceName)
+		if err != nil {
+			slog.Warn("无法订阅操作系统网络状态通知，将仅依赖定时轮询兜底", slog.Any("错误", err))
+		} else {
+			defer watcher.Close()
+			eventsCh = watcher.Events()
+			slog.Info("已订阅操作系统网络状态通知，定时轮询将作为兜底安全网继续运行")
+		}
+	}
+
+	// --- 构建共享状态的 Supervisor，供主循环和HTTP控制API共同驱动 ---
+	health := healthCheckConfig{url: *healthURLFlag, timeout: *healthTimeoutFlag, retries: *healthRetriesFlag}
+	supervisor := NewSupervisor(provider, candidates, effectiveIfaceName, *roamRSSIDropFlag, health)
+
+	if *apiAddrFlag != "" {
+		if err := startAPIServer(*apiAddrFlag, supervisor, logLevelFlag.levels); err != nil {
+			slog.Error("启动本地控制API失败", slog.Any("错误", err))
+			os.Exit(1)
+		}
+		slog.Info("本地控制API已启动", slog.String("监听地址", *apiAddrFlag))
+	}
+
 	// --- 主循环 ---
+	// ticker 现在只是一个兜底的安全网：绝大多数重连都应该由 eventsCh 上的
+	// 系统通知触发，从而消除固定轮询间隔带来的重连延迟。
 	ticker := time.NewTicker(*checkIntervalFlag)
 	defer ticker.Stop()
 
-	performCheckAndConnect(*targetSSIDFlag, effectiveIfaceName)
+	supervisor.Check()
 
 	for {
 		select {
+		case evt, ok := <-eventsCh:
+			if !ok {
+				eventsCh = nil
+				continue
+			}
+			slog.Debug("收到操作系统网络状态通知", slog.String("事件", evt.Type.String()), slog.String("接口", evt.Interface))
+			supervisor.Check()
 		case currentTime := <-ticker.C:
-			slog.Debug("定时检查触发", slog.Time("时间", currentTime))
-			performCheckAndConnect(*targetSSIDFlag, effectiveIfaceName)
+			slog.Debug("定时检查触发(兜底)", slog.Time("时间", currentTime))
+			supervisor.Check()
 		}
 	}
 }
 
-// performCheckAndConnect 执行一次完整的检查和连接尝试逻辑。
-func performCheckAndConnect(targetSSID, interfaceName string) {
-	slog.Info("开始检查WiFi连接状态...", slog.String("SSID", targetSSID), slog.String("接口", interfaceName))
-	connected, err := isConnected(targetSSID, interfaceName)
-	if err != nil {
-		slog.Error("检查连接状态时出错", slog.Any("错误", err))
-		return
-	}
-
-	if connected {
-		slog.Info("已连接到目标WiFi", slog.String("SSID", targetSSID))
-	} else {
-		slog.Warn("未连接到目标WiFi，开始处理...", slog.String("目标SSID", targetSSID))
-		networkVisible, availErr := isNetworkAvailable(targetSSID, interfaceName)
-		if availErr != nil {
-			slog.Error("检查网络可见性时出错", slog.String("SSID", targetSSID), slog.Any("错误", availErr))
-			return
+// isCandidate 判断 ssid 是否在候选列表中。
+func isCandidate(candidates []ssidCandidate, ssid string) bool {
+	for _, c := range candidates {
+		if c.SSID == ssid {
+			return true
 		}
+	}
+	return false
+}
 
-		if networkVisible {
-			slog.Info("目标网络可见，尝试连接...", slog.String("SSID", targetSSID))
-			connectErr := connectToWifi(targetSSID, interfaceName)
-			if connectErr != nil {
-				slog.Error("连接尝试失败", slog.String("SSID", targetSSID), slog.Any("错误", connectErr))
-			} else {
-				slog.Info("连接命令已发送，将在下一个周期确认连接状态。")
-			}
-		} else {
-			slog.Warn("目标网络当前不可见，跳过连接尝试。", slog.String("SSID", targetSSID))
+// candidatePriority 返回 ssid 在候选列表中的优先级（索引），未找到时返回列表长度。
+func candidatePriority(candidates []ssidCandidate, ssid string) int {
+	for i, c := range candidates {
+		if c.SSID == ssid {
+			return i
 		}
 	}
+	return len(candidates)
 }
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignal 是触发重新加载日志级别配置的系统信号。Unix系统上沿用
+// 传统日志服务的约定，使用 SIGHUP。
+var reloadSignal os.Signal = syscall.SIGHUP
@@ -0,0 +1,9 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// reloadSignal 在Windows上没有与 SIGHUP 对应的信号，置空以禁用基于信号的重载。
+// Windows上仍可通过 POST /loglevel 在不重启的情况下调整日志级别。
+var reloadSignal os.Signal
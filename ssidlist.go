@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+// ssidCandidate 是多SSID候选列表中的一项。候选项在 -ssid 参数中出现的顺序
+// 即为优先级（越靠前优先级越高），MinRSSI 可选，单位为dBm。
+type ssidCandidate struct {
+	SSID    string
+	MinRSSI int
+	HasMin  bool
+}
+
+// parseSSIDList 解析形如 "HomeMain:-65,HomeGuest:-70,Mobile" 的候选列表：
+// 每一项是 SSID，或者 "SSID:最小RSSI(dBm)"。没有附带阈值的候选项不设下限。
+func parseSSIDList(spec string) ([]ssidCandidate, error) {
+	var candidates []ssidCandidate
+	for _, raw := range strings.Split(spec, ",") {
+		item := strings.TrimSpace(raw)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, ":", 2)
+		ssid := strings.TrimSpace(parts[0])
+		if ssid == "" {
+			return nil, fmt.Errorf("无效的SSID候选项: %q", raw)
+		}
+		c := ssidCandidate{SSID: ssid}
+		if len(parts) == 2 {
+			threshold, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return nil, fmt.Errorf("候选项 %q 的RSSI阈值 %q 无效: %w", raw, parts[1], err)
+			}
+			c.MinRSSI = threshold
+			c.HasMin = true
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("候选SSID列表为空")
+	}
+	return candidates, nil
+}
+
+// scoredNetwork 是参与候选选择的一个可见网络，附带它在候选列表中的优先级与dBm信号值。
+type scoredNetwork struct {
+	wifi.NetworkInfo
+	priority int
+	rssiDBm  int
+}
+
+// rankNetworks 在可见网络中筛出所有候选，按优先级排序：先按候选列表中的优先级排序，
+// 优先级相同（同一SSID下的多个BSSID）时按RSSI更强者优先，
+// 并剔除任何低于其配置信号门限的候选。
+func rankNetworks(candidates []ssidCandidate, networks []wifi.NetworkInfo) []scoredNetwork {
+	priority := make(map[string]int, len(candidates))
+	minRSSI := make(map[string]int, len(candidates))
+	for i, c := range candidates {
+		priority[c.SSID] = i
+		if c.HasMin {
+			minRSSI[c.SSID] = c.MinRSSI
+		}
+	}
+
+	var scored []scoredNetwork
+	for _, n := range networks {
+		p, known := priority[n.SSID]
+		if !known {
+			continue
+		}
+		rssi := wifi.PercentToDBm(n.SignalPct)
+		if threshold, hasThreshold := minRSSI[n.SSID]; hasThreshold && rssi < threshold {
+			continue
+		}
+		scored = append(scored, scoredNetwork{NetworkInfo: n, priority: p, rssiDBm: rssi})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].priority != scored[j].priority {
+			return scored[i].priority < scored[j].priority
+		}
+		return scored[i].rssiDBm > scored[j].rssiDBm
+	})
+	return scored
+}
+
+// selectBestNetwork 在可见网络中选出最优候选，参见 rankNetworks。
+func selectBestNetwork(candidates []ssidCandidate, networks []wifi.NetworkInfo) (scoredNetwork, bool) {
+	scored := rankNetworks(candidates, networks)
+	if len(scored) == 0 {
+		return scoredNetwork{}, false
+	}
+	return scored[0], true
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+func TestParseSSIDList(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain:-65,HomeGuest:-70,Mobile")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("len(candidates) = %d, want 3", len(candidates))
+	}
+	if candidates[0].SSID != "HomeMain" || !candidates[0].HasMin || candidates[0].MinRSSI != -65 {
+		t.Errorf("candidates[0] = %+v, want SSID=HomeMain MinRSSI=-65", candidates[0])
+	}
+	if candidates[2].SSID != "Mobile" || candidates[2].HasMin {
+		t.Errorf("candidates[2] = %+v, want SSID=Mobile with no threshold", candidates[2])
+	}
+}
+
+func TestParseSSIDListEmpty(t *testing.T) {
+	if _, err := parseSSIDList("  ,  "); err == nil {
+		t.Fatalf("parseSSIDList() error = nil, want error for empty list")
+	}
+}
+
+func TestSelectBestNetworkPrefersPriorityThenRSSI(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain:-85,HomeGuest")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+	networks := []wifi.NetworkInfo{
+		{SSID: "HomeGuest", SignalPct: 90},
+		{SSID: "HomeMain", SignalPct: 40},
+		{SSID: "Unrelated", SignalPct: 100},
+	}
+
+	best, found := selectBestNetwork(candidates, networks)
+	if !found {
+		t.Fatalf("selectBestNetwork() found = false, want true")
+	}
+	if best.SSID != "HomeMain" {
+		t.Errorf("best.SSID = %q, want HomeMain (higher priority wins over weaker RSSI)", best.SSID)
+	}
+}
+
+func TestSelectBestNetworkSkipsBelowThreshold(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain:-65")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+	networks := []wifi.NetworkInfo{{SSID: "HomeMain", SignalPct: 10}} // weak signal, below threshold
+
+	if _, found := selectBestNetwork(candidates, networks); found {
+		t.Errorf("selectBestNetwork() found = true, want false (below MinRSSI threshold)")
+	}
+}
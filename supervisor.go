@@ -0,0 +1,267 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+// SupervisorStatus 是 Supervisor 状态的一份只读快照，供 API 处理函数序列化返回。
+type SupervisorStatus struct {
+	Interface           string    `json:"interface"`
+	Backend             string    `json:"backend"`
+	Connected           bool      `json:"connected"`
+	SSID                string    `json:"ssid"`
+	BSSID               string    `json:"bssid"`
+	SignalDBm           int       `json:"signal_dbm"`
+	ConnectedSince      time.Time `json:"connected_since,omitzero"`
+	UptimeSeconds       float64   `json:"uptime_seconds"`
+	ReconnectCount      int       `json:"reconnect_count"`
+	LastReconnectReason string    `json:"last_reconnect_reason,omitempty"`
+	LastCheckAt         time.Time `json:"last_check_at,omitzero"`
+	LastCheckError      string    `json:"last_check_error,omitempty"`
+}
+
+// StateEvent 是一次状态转换通知，通过 /events 以 Server-Sent Events 的形式推送给订阅者。
+type StateEvent struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"` // "connect_requested", "disconnected", "roamed", "health_check_failed", "scan"
+	SSID   string    `json:"ssid,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Supervisor 持有重连循环的全部可变状态，被定时轮询/事件驱动的主循环
+// 和HTTP控制API共享，所有对外暴露的方法都通过 mu 互斥访问内部状态。
+type Supervisor struct {
+	provider        wifi.Provider
+	candidates      []ssidCandidate
+	interfaceName   string
+	roamRSSIDropDBm int
+	health          healthCheckConfig
+
+	mu          sync.Mutex
+	status      SupervisorStatus
+	lastScan    []wifi.NetworkInfo
+	subscribers map[chan StateEvent]struct{}
+}
+
+// NewSupervisor 创建一个绑定到指定Provider/接口/候选列表的 Supervisor。
+func NewSupervisor(provider wifi.Provider, candidates []ssidCandidate, interfaceName string, roamRSSIDropDBm int, health healthCheckConfig) *Supervisor {
+	return &Supervisor{
+		provider:        provider,
+		candidates:      candidates,
+		interfaceName:   interfaceName,
+		roamRSSIDropDBm: roamRSSIDropDBm,
+		health:          health,
+		status:          SupervisorStatus{Interface: interfaceName, Backend: provider.Name(), SignalDBm: -100},
+		subscribers:     make(map[chan StateEvent]struct{}),
+	}
+}
+
+// Status 返回当前状态的一份快照。
+func (s *Supervisor) Status() SupervisorStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.status
+	if status.Connected && !status.ConnectedSince.IsZero() {
+		status.UptimeSeconds = time.Since(status.ConnectedSince).Seconds()
+	}
+	return status
+}
+
+// Scan 触发一次新的扫描，更新并返回最新的可见网络列表。
+func (s *Supervisor) Scan() ([]wifi.NetworkInfo, error) {
+	networks, err := s.provider.Scan(s.interfaceName)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.lastScan = networks
+	s.mu.Unlock()
+	s.publish(StateEvent{Time: time.Now(), Kind: "scan"})
+	return networks, nil
+}
+
+// Connect 发起一次临时的手动连接请求，绕过候选列表优先级选择逻辑。
+func (s *Supervisor) Connect(ssid string, creds wifi.Credentials) error {
+	if err := s.provider.Connect(s.interfaceName, ssid, creds); err != nil {
+		s.recordCheckError(err)
+		return err
+	}
+	s.publish(StateEvent{Time: time.Now(), Kind: "connect_requested", SSID: ssid})
+	return nil
+}
+
+// Disconnect 主动断开当前接口的连接。
+func (s *Supervisor) Disconnect() error {
+	err := s.provider.Disconnect(s.interfaceName)
+	if err != nil {
+		s.recordCheckError(err)
+		return err
+	}
+	s.mu.Lock()
+	s.status.Connected = false
+	s.status.SSID = ""
+	s.status.ConnectedSince = time.Time{}
+	s.mu.Unlock()
+	s.publish(StateEvent{Time: time.Now(), Kind: "disconnected", Reason: "manual"})
+	return nil
+}
+
+// Subscribe 注册一个状态转换事件的订阅通道，供 /events SSE 处理函数使用。
+// 返回的取消函数必须在处理函数退出时调用，以避免订阅者泄漏。
+func (s *Supervisor) Subscribe() (ch chan StateEvent, cancel func()) {
+	ch = make(chan StateEvent, 16)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		close(ch)
+		s.mu.Unlock()
+	}
+}
+
+// publish 把一个事件广播给所有当前订阅者，订阅者通道已满时直接丢弃，不阻塞调用方。
+func (s *Supervisor) publish(evt StateEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+func (s *Supervisor) recordCheckError(err error) {
+	s.mu.Lock()
+	s.status.LastCheckAt = time.Now()
+	s.status.LastCheckError = err.Error()
+	s.mu.Unlock()
+}
+
+// Check 执行一次完整的检查和连接尝试逻辑：确认当前连接状态，必要时在候选SSID列表中
+// 选出信号最好、优先级最高的一个并连接，如果已经连接到某个候选但有明显更优的候选可见，
+// 则触发一次漫游重连。主循环（事件通知与定时轮询）通过它驱动自动重连；
+// HTTP API 不调用 Check，而是通过 Scan/Connect/Disconnect 直接操作并更新状态。
+func (s *Supervisor) Check() {
+	withComponent("connect").Info("开始检查WiFi连接状态...", slog.String("接口", s.interfaceName))
+	status, err := s.provider.Status(s.interfaceName)
+	s.mu.Lock()
+	s.status.LastCheckAt = time.Now()
+	s.mu.Unlock()
+	if err != nil {
+		withComponent("connect").Error("检查连接状态时出错", slog.Any("错误", err))
+		s.recordCheckError(err)
+		return
+	}
+
+	networks, err := s.provider.Scan(s.interfaceName)
+	if err != nil {
+		withComponent("scan").Error("扫描可见网络时出错", slog.Any("错误", err))
+		s.recordCheckError(err)
+		return
+	}
+	s.mu.Lock()
+	s.lastScan = networks
+	s.mu.Unlock()
+	ranked := rankNetworks(s.candidates, networks)
+	best, found := scoredNetwork{}, len(ranked) > 0
+	if found {
+		best = ranked[0]
+	}
+
+	if status.Connected && isCandidate(s.candidates, status.SSID) {
+		if !found || best.BSSID == status.BSSID {
+			withComponent("connect").Info("已连接到候选WiFi，无需处理", slog.String("SSID", status.SSID))
+			s.recordConnected(status)
+			return
+		}
+		currentRSSI := wifi.PercentToDBm(status.SignalPct)
+		currentPriority := candidatePriority(s.candidates, status.SSID)
+		shouldRoam := best.priority < currentPriority || (best.priority == currentPriority && best.rssiDBm-currentRSSI >= s.roamRSSIDropDBm)
+		if !shouldRoam {
+			withComponent("connect").Debug("发现候选网络，但尚未超过漫游迟滞阈值，保持当前连接",
+				slog.String("当前SSID", status.SSID), slog.Int("当前RSSI", currentRSSI),
+				slog.String("候选SSID", best.SSID), slog.Int("候选RSSI", best.rssiDBm))
+			s.recordConnected(status)
+			return
+		}
+		withComponent("connect").Info("触发漫游重连", slog.String("当前SSID", status.SSID), slog.Int("当前RSSI", currentRSSI),
+			slog.String("目标SSID", best.SSID), slog.Int("目标RSSI", best.rssiDBm))
+		s.publish(StateEvent{Time: time.Now(), Kind: "roamed", SSID: best.SSID, Reason: "stronger_candidate"})
+	} else if status.Connected {
+		withComponent("connect").Warn("已连接到非候选SSID，将切换到候选列表", slog.String("当前SSID", status.SSID))
+		s.recordDisconnected()
+	} else {
+		withComponent("connect").Warn("未连接到任何候选WiFi，开始处理...")
+		s.recordDisconnected()
+	}
+
+	if !found {
+		withComponent("scan").Warn("没有可见且满足信号门限的候选网络，跳过连接尝试。")
+		return
+	}
+
+	s.connectWithHealthCheck(ranked)
+}
+
+// connectWithHealthCheck 依次尝试 ranked 中的候选网络：连接成功后立即跑一轮
+// HealthCheck 验证真实连通性，验证失败则视为该候选不可用，强制断开并
+// 尝试下一个候选，直到某个候选通过验证或候选列表耗尽。
+func (s *Supervisor) connectWithHealthCheck(ranked []scoredNetwork) {
+	connectLog := withComponent("connect")
+	for _, candidate := range ranked {
+		connectLog.Info("尝试连接到候选网络...", slog.String("SSID", candidate.SSID), slog.Int("信号(dBm)", candidate.rssiDBm))
+		if err := s.provider.Connect(s.interfaceName, candidate.SSID, wifi.Credentials{}); err != nil {
+			connectLog.Error("连接尝试失败", slog.String("SSID", candidate.SSID), slog.Any("错误", err))
+			s.recordCheckError(err)
+			continue
+		}
+
+		if err := healthCheckWithRetries(s.interfaceName, s.health); err != nil {
+			withComponent("health").Warn("连接后健康检查未通过，放弃该候选并断开",
+				slog.String("SSID", candidate.SSID), slog.Any("错误", err))
+			if disconnectErr := s.provider.Disconnect(s.interfaceName); disconnectErr != nil {
+				connectLog.Error("断开不健康的连接失败", slog.String("SSID", candidate.SSID), slog.Any("错误", disconnectErr))
+			}
+			s.recordCheckError(err)
+			s.publish(StateEvent{Time: time.Now(), Kind: "health_check_failed", SSID: candidate.SSID, Reason: err.Error()})
+			continue
+		}
+
+		connectLog.Info("连接命令已发送并通过健康检查。", slog.String("SSID", candidate.SSID))
+		return
+	}
+	connectLog.Warn("所有候选网络均连接失败或未通过健康检查，等待下一个检查周期重试。")
+}
+
+// recordConnected 更新状态快照为"已连接"，必要时增加重连计数。
+func (s *Supervisor) recordConnected(status wifi.InterfaceStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wasConnected := s.status.Connected && s.status.SSID == status.SSID
+	s.status.Connected = true
+	s.status.SSID = status.SSID
+	s.status.BSSID = status.BSSID
+	s.status.SignalDBm = wifi.PercentToDBm(status.SignalPct)
+	if !wasConnected {
+		s.status.ConnectedSince = time.Now()
+		s.status.ReconnectCount++
+	}
+}
+
+// recordDisconnected 更新状态快照为"未连接"。
+func (s *Supervisor) recordDisconnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Connected = false
+	s.status.SSID = ""
+	s.status.BSSID = ""
+	s.status.SignalDBm = -100
+	s.status.ConnectedSince = time.Time{}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/htnosakura/go-reconnectwifi/wifi"
+)
+
+// fakeProvider 是用于测试 Supervisor 的最小 wifi.Provider 实现。
+type fakeProvider struct {
+	status       wifi.InterfaceStatus
+	networks     []wifi.NetworkInfo
+	connectCalls []string
+}
+
+func (f *fakeProvider) Name() string                  { return "fake" }
+func (f *fakeProvider) Interfaces() ([]string, error) { return []string{"wlan0"}, nil }
+func (f *fakeProvider) Scan(iface string) ([]wifi.NetworkInfo, error) {
+	return f.networks, nil
+}
+func (f *fakeProvider) Status(iface string) (wifi.InterfaceStatus, error) {
+	return f.status, nil
+}
+func (f *fakeProvider) Connect(iface, ssid string, creds wifi.Credentials) error {
+	f.connectCalls = append(f.connectCalls, ssid)
+	return nil
+}
+func (f *fakeProvider) Disconnect(iface string) error { return nil }
+
+func TestCheckRoamsWithinSameSSIDWhenBSSIDStrongerCandidateVisible(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+
+	provider := &fakeProvider{
+		status: wifi.InterfaceStatus{
+			Interface: "wlan0", Connected: true,
+			SSID: "HomeMain", BSSID: "AA:BB:CC:DD:EE:01", SignalPct: 20, // 弱信号，当前关联的AP
+		},
+		networks: []wifi.NetworkInfo{
+			{SSID: "HomeMain", BSSID: "AA:BB:CC:DD:EE:01", SignalPct: 20}, // 当前AP
+			{SSID: "HomeMain", BSSID: "AA:BB:CC:DD:EE:02", SignalPct: 90}, // 同SSID下信号强得多的另一个AP
+		},
+	}
+
+	health := healthCheckConfig{url: "http://127.0.0.1:0/", retries: 0}
+	s := NewSupervisor(provider, candidates, "wlan0", 15, health)
+	s.Check()
+
+	if len(provider.connectCalls) == 0 {
+		t.Fatalf("Connect() was never called, want a roam attempt to the stronger same-SSID BSSID")
+	}
+}
+
+func TestCheckClearsStatusWhenConnectedToNonCandidateSSID(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+
+	provider := &fakeProvider{
+		status: wifi.InterfaceStatus{
+			Interface: "wlan0", Connected: true,
+			SSID: "CoffeeShop", BSSID: "AA:BB:CC:DD:EE:09", SignalPct: 80, // 非候选SSID
+		},
+		// 没有任何候选网络可见
+	}
+
+	health := healthCheckConfig{url: "http://127.0.0.1:0/", retries: 0}
+	s := NewSupervisor(provider, candidates, "wlan0", 15, health)
+	s.status.Connected = true
+	s.status.SSID = "HomeMain" // 模拟上一轮还连着候选网络时留下的陈旧状态
+	s.Check()
+
+	got := s.Status()
+	if got.Connected || got.SSID != "" {
+		t.Fatalf("Status() = %+v, want Connected=false SSID=\"\" instead of stale HomeMain state", got)
+	}
+}
+
+func TestCheckDoesNotRoamWhenAlreadyOnBestBSSID(t *testing.T) {
+	candidates, err := parseSSIDList("HomeMain")
+	if err != nil {
+		t.Fatalf("parseSSIDList() error = %v", err)
+	}
+
+	provider := &fakeProvider{
+		status: wifi.InterfaceStatus{
+			Interface: "wlan0", Connected: true,
+			SSID: "HomeMain", BSSID: "AA:BB:CC:DD:EE:01", SignalPct: 90,
+		},
+		networks: []wifi.NetworkInfo{
+			{SSID: "HomeMain", BSSID: "AA:BB:CC:DD:EE:01", SignalPct: 90},
+		},
+	}
+
+	health := healthCheckConfig{url: "http://127.0.0.1:0/", retries: 0}
+	s := NewSupervisor(provider, candidates, "wlan0", 15, health)
+	s.Check()
+
+	if len(provider.connectCalls) != 0 {
+		t.Errorf("Connect() calls = %v, want none (already connected to the only visible BSSID)", provider.connectCalls)
+	}
+}
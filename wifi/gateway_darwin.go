@@ -0,0 +1,26 @@
+//go:build darwin
+
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+)
+
+// DefaultGateway 返回指定接口当前路由表中的默认网关地址，
+// 通过解析 'route -n get default -ifscope <iface>' 的输出得到。
+func DefaultGateway(iface string) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "route", "-n", "get", "default", "-ifscope", iface).Output()
+	if err != nil {
+		return nil, fmt.Errorf("执行 'route -n get default -ifscope %s' 失败: %w", iface, err)
+	}
+	gw := parseRouteGetGateway(string(out))
+	if gw == nil {
+		return nil, fmt.Errorf("未能从路由表中解析出接口 %s 的默认网关", iface)
+	}
+	return gw, nil
+}
@@ -0,0 +1,26 @@
+//go:build linux
+
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+)
+
+// DefaultGateway 返回指定接口当前路由表中的默认网关地址，
+// 通过解析 'ip route show default dev <iface>' 的输出得到。
+func DefaultGateway(iface string) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "ip", "route", "show", "default", "dev", iface).Output()
+	if err != nil {
+		return nil, fmt.Errorf("执行 'ip route show default dev %s' 失败: %w", iface, err)
+	}
+	gw := parseIPRouteDefaultGateway(string(out))
+	if gw == nil {
+		return nil, fmt.Errorf("未能从路由表中解析出接口 %s 的默认网关", iface)
+	}
+	return gw, nil
+}
@@ -0,0 +1,54 @@
+package wifi
+
+import (
+	"net"
+	"strings"
+)
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// parseIPRouteDefaultGateway 从 'ip route show default dev <iface>' 的输出中解析默认网关地址。
+// 典型输出形如 "default via 192.168.1.1 dev wlan0 proto dhcp metric 600"。
+func parseIPRouteDefaultGateway(output string) net.IP {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		for i, f := range fields {
+			if f == "via" && i+1 < len(fields) {
+				return net.ParseIP(fields[i+1])
+			}
+		}
+	}
+	return nil
+}
+
+// parseRouteGetGateway 从 macOS 'route -n get default -ifscope <iface>' 的输出中解析网关地址。
+// 典型输出中包含一行 "    gateway: 192.168.1.1"。
+func parseRouteGetGateway(output string) net.IP {
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if after, ok := strings.CutPrefix(trimmed, "gateway:"); ok {
+			return net.ParseIP(strings.TrimSpace(after))
+		}
+	}
+	return nil
+}
+
+// parseNetshRouteGateway 从 'netsh interface ipv4 show config' 的输出中解析默认网关地址，
+// 兼容中英文两种本地化输出 ("默认网关"/"Default Gateway")。
+func parseNetshRouteGateway(output string) net.IP {
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		for _, prefix := range []string{"默认网关", "Default Gateway"} {
+			after, ok := strings.CutPrefix(trimmed, prefix)
+			if !ok {
+				continue
+			}
+			after = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(after), ":"))
+			if ip := net.ParseIP(after); ip != nil {
+				return ip
+			}
+		}
+	}
+	return nil
+}
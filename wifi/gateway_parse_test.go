@@ -0,0 +1,47 @@
+package wifi
+
+import "testing"
+
+// 以下golden输出摘自真实路由查询命令的结果。
+
+const goldenIPRouteOutput = `default via 192.168.1.1 dev wlan0 proto dhcp metric 600 `
+
+const goldenRouteGetOutput = `   route to: default
+destination: default
+       mask: default
+    gateway: 192.168.1.1
+  interface: en0
+`
+
+const goldenNetshRouteConfigOutput = `
+WLAN 的配置
+-------------------------------------------------------
+    DHCP 已启用:                          是
+    IP 地址:                              192.168.1.50
+    子网前缀:                             192.168.1.0/24 (掩码 255.255.255.0)
+    默认网关:                             192.168.1.1
+`
+
+func TestParseIPRouteDefaultGateway(t *testing.T) {
+	got := parseIPRouteDefaultGateway(goldenIPRouteOutput)
+	if got == nil || got.String() != "192.168.1.1" {
+		t.Fatalf("parseIPRouteDefaultGateway() = %v, want 192.168.1.1", got)
+	}
+	if got := parseIPRouteDefaultGateway("no default route"); got != nil {
+		t.Fatalf("parseIPRouteDefaultGateway() = %v, want nil", got)
+	}
+}
+
+func TestParseRouteGetGateway(t *testing.T) {
+	got := parseRouteGetGateway(goldenRouteGetOutput)
+	if got == nil || got.String() != "192.168.1.1" {
+		t.Fatalf("parseRouteGetGateway() = %v, want 192.168.1.1", got)
+	}
+}
+
+func TestParseNetshRouteGateway(t *testing.T) {
+	got := parseNetshRouteGateway(goldenNetshRouteConfigOutput)
+	if got == nil || got.String() != "192.168.1.1" {
+		t.Fatalf("parseNetshRouteGateway() = %v, want 192.168.1.1", got)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"syscall"
+)
+
+// DefaultGateway 返回指定接口当前路由表中的默认网关地址，
+// 通过解析 'netsh interface ipv4 show config' 的输出得到。
+func DefaultGateway(iface string) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "netsh", "interface", "ipv4", "show", "config", fmt.Sprintf("name=%q", iface))
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("执行 'netsh interface ipv4 show config name=%q' 失败: %w", iface, err)
+	}
+	gw := parseNetshRouteGateway(string(out))
+	if gw == nil {
+		return nil, fmt.Errorf("未能从路由配置中解析出接口 %s 的默认网关", iface)
+	}
+	return gw, nil
+}
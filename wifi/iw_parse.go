@@ -0,0 +1,104 @@
+package wifi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// parseIwDevInterfaces 从 `iw dev` 的输出中解析出所有无线接口名称。
+func parseIwDevInterfaces(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		if after, ok := strings.CutPrefix(strings.TrimSpace(line), "Interface "); ok {
+			names = append(names, strings.TrimSpace(after))
+		}
+	}
+	return names
+}
+
+// parseIwLink 解析 `iw dev <iface> link` 的输出。未关联时输出为 "Not connected."。
+// 已关联时第一行形如 "Connected to aa:bb:cc:dd:ee:ff (on wlan0)"，随后的缩进行里
+// 包含 "SSID: ..." 和 "signal: -58 dBm"。
+func parseIwLink(output string) (status InterfaceStatus) {
+	status.SignalPct = -1
+	lines := strings.Split(output, "\n")
+	if len(lines) == 0 {
+		return status
+	}
+	first := strings.TrimSpace(lines[0])
+	after, ok := strings.CutPrefix(first, "Connected to ")
+	if !ok {
+		return status
+	}
+	status.Connected = true
+	if idx := strings.Index(after, " ("); idx >= 0 {
+		status.BSSID = after[:idx]
+	} else {
+		status.BSSID = after
+	}
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "SSID:"):
+			status.SSID = strings.TrimSpace(strings.TrimPrefix(trimmed, "SSID:"))
+		case strings.HasPrefix(trimmed, "signal:"):
+			if dbm, ok := parseDBmField(trimmed, "signal:"); ok {
+				status.SignalPct = DBmToPercent(dbm)
+			}
+		}
+	}
+	return status
+}
+
+// parseDBmField 从形如 "signal: -58 dBm" 或 "signal: -58.00 dBm" 的一行中
+// 提取dBm数值（iw link 用整数，iw scan 用一位小数，这里统一按浮点数解析后取整）。
+func parseDBmField(line, prefix string) (int, bool) {
+	value := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+	value = strings.TrimSuffix(value, " dBm")
+	dbm, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(dbm), true
+}
+
+// parseIwScan 解析 `iw dev <iface> scan` 的输出，每个网络以一行 "BSS <bssid>(on <iface>)..."
+// 开头，随后的缩进行包含 "SSID: ..." 和 "signal: -58.00 dBm"。
+func parseIwScan(output string) []NetworkInfo {
+	var networks []NetworkInfo
+	var current *NetworkInfo
+	flush := func() {
+		if current != nil && current.SSID != "" {
+			networks = append(networks, *current)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if after, ok := strings.CutPrefix(line, "BSS "); ok {
+			flush()
+			bssid := after
+			if idx := strings.Index(bssid, "("); idx >= 0 {
+				bssid = bssid[:idx]
+			}
+			current = &NetworkInfo{BSSID: strings.TrimSpace(bssid)}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "SSID:"):
+			current.SSID = strings.TrimSpace(strings.TrimPrefix(trimmed, "SSID:"))
+		case strings.HasPrefix(trimmed, "signal:"):
+			if dbm, ok := parseDBmField(trimmed, "signal:"); ok {
+				current.SignalPct = DBmToPercent(dbm)
+			}
+		}
+	}
+	flush()
+	return networks
+}
@@ -0,0 +1,129 @@
+package wifi
+
+import "strings"
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// parseNetshInterfaceNames 从 'netsh wlan show interfaces' 的输出中解析出所有接口名称。
+func parseNetshInterfaceNames(output string) []string {
+	var names []string
+	for line := range strings.SplitSeq(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "名称") || strings.HasPrefix(trimmed, "Name") {
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				if name := strings.TrimSpace(parts[1]); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// parseNetshInterfaceStatus 从 'netsh wlan show interfaces' 的输出中
+// 解析出指定接口 (iface) 的关联状态。
+func parseNetshInterfaceStatus(output, iface string) InterfaceStatus {
+	status := InterfaceStatus{Interface: iface, SignalPct: -1}
+
+	const (
+		keywordInterfaceName = "名称"
+		keywordSSID          = "SSID"
+		keywordBSSID         = "BSSID"
+		keywordState         = "状态"
+		keywordSignal        = "信号"
+		keywordConnectedEN   = "已连接"
+	)
+
+	inBlock := false
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, keywordInterfaceName) && strings.Contains(trimmed, ":") {
+			parts := strings.SplitN(trimmed, ":", 2)
+			inBlock = len(parts) == 2 && strings.TrimSpace(parts[1]) == iface
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, keywordSSID) && !strings.Contains(trimmed, "AP BSSID") && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				status.SSID = strings.TrimSpace(parts[1])
+			}
+		case strings.HasPrefix(trimmed, keywordBSSID) && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				status.BSSID = strings.TrimSpace(parts[1])
+			}
+		case strings.HasPrefix(trimmed, keywordState) && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				status.Connected = strings.TrimSpace(parts[1]) == keywordConnectedEN
+			}
+		case strings.HasPrefix(trimmed, keywordSignal) && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				status.SignalPct = parsePercent(strings.TrimSpace(parts[1]))
+			}
+		}
+	}
+	return status
+}
+
+// parseNetshNetworks 从 'netsh wlan show networks mode=bssid' 的输出中
+// 解析出所有可见的网络及其信号强度。
+func parseNetshNetworks(output string) []NetworkInfo {
+	var (
+		networks    []NetworkInfo
+		currentSSID string
+	)
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "SSID ") && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				currentSSID = strings.TrimSpace(parts[1])
+			}
+		case (strings.HasPrefix(trimmed, "BSSID ") || strings.HasPrefix(trimmed, "BSSID")) && strings.Contains(trimmed, ":"):
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			networks = append(networks, NetworkInfo{
+				SSID:  currentSSID,
+				BSSID: strings.TrimSpace(parts[1]),
+			})
+		case (strings.HasPrefix(trimmed, "信号") || strings.HasPrefix(trimmed, "Signal")) && strings.Contains(trimmed, ":"):
+			if len(networks) == 0 {
+				continue
+			}
+			parts := strings.SplitN(trimmed, ":", 2)
+			if len(parts) == 2 {
+				networks[len(networks)-1].SignalPct = parsePercent(strings.TrimSpace(parts[1]))
+			}
+		}
+	}
+	return networks
+}
+
+// parsePercent 解析形如 "87%" 的字符串，失败时返回 -1。
+func parsePercent(s string) int {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	n := 0
+	if s == "" {
+		return -1
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package wifi
+
+import "testing"
+
+// 以下golden输出摘自真实 `netsh wlan show interfaces`/`show networks` 命令的中文本地化结果。
+
+const goldenInterfacesOutput = `
+接口名称 : Wi-Fi
+
+    名称                   : Wi-Fi
+    描述                   : Intel(R) Wi-Fi 6 AX201 160MHz
+    GUID                   : 12345678-1234-1234-1234-123456789abc
+    物理地址               : aa:bb:cc:dd:ee:ff
+    状态                   : 已连接
+    SSID                   : HomeMain
+    BSSID                  : 11:22:33:44:55:66
+    网络类型               : 基础结构
+    无线电类型             : 802.11ac
+    信号                   : 87%
+`
+
+const goldenNetworksOutput = `
+接口 Wi-Fi 上的策略 : 所有可用网络
+
+SSID 1 : HomeMain
+    网络类型            : 基础结构
+    身份验证            : WPA2-个人
+    加密                : CCMP
+    BSSID 1                   : 11:22:33:44:55:66
+         信号             : 87%
+         无线电类型       : 802.11ac
+SSID 2 : HomeGuest
+    网络类型            : 基础结构
+    身份验证            : 开放式
+    加密                : 无
+    BSSID 1                   : 11:22:33:44:55:99
+         信号             : 54%
+         无线电类型       : 802.11n
+`
+
+func TestParseNetshInterfaceNames(t *testing.T) {
+	names := parseNetshInterfaceNames(goldenInterfacesOutput)
+	if len(names) != 1 || names[0] != "Wi-Fi" {
+		t.Fatalf("parseNetshInterfaceNames() = %v, want [Wi-Fi]", names)
+	}
+}
+
+func TestParseNetshInterfaceStatus(t *testing.T) {
+	status := parseNetshInterfaceStatus(goldenInterfacesOutput, "Wi-Fi")
+	if !status.Connected {
+		t.Fatalf("status.Connected = false, want true")
+	}
+	if status.SSID != "HomeMain" {
+		t.Fatalf("status.SSID = %q, want HomeMain", status.SSID)
+	}
+	if status.BSSID != "11:22:33:44:55:66" {
+		t.Fatalf("status.BSSID = %q, want 11:22:33:44:55:66", status.BSSID)
+	}
+	if status.SignalPct != 87 {
+		t.Fatalf("status.SignalPct = %d, want 87", status.SignalPct)
+	}
+}
+
+func TestParseNetshInterfaceStatusUnknownInterface(t *testing.T) {
+	status := parseNetshInterfaceStatus(goldenInterfacesOutput, "eth0")
+	if status.Connected || status.SSID != "" {
+		t.Fatalf("status = %+v, want zero value for unmatched interface", status)
+	}
+}
+
+func TestParseNetshNetworks(t *testing.T) {
+	networks := parseNetshNetworks(goldenNetworksOutput)
+	if len(networks) != 2 {
+		t.Fatalf("len(networks) = %d, want 2", len(networks))
+	}
+	if networks[0].SSID != "HomeMain" || networks[0].SignalPct != 87 {
+		t.Fatalf("networks[0] = %+v, want SSID=HomeMain SignalPct=87", networks[0])
+	}
+	if networks[1].SSID != "HomeGuest" || networks[1].SignalPct != 54 {
+		t.Fatalf("networks[1] = %+v, want SSID=HomeGuest SignalPct=54", networks[1])
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	cases := map[string]int{
+		"87%": 87,
+		"0%":  0,
+		"":    -1,
+		"abc": -1,
+	}
+	for in, want := range cases {
+		if got := parsePercent(in); got != want {
+			t.Errorf("parsePercent(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
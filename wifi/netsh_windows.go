@@ -0,0 +1,123 @@
+//go:build windows
+
+package wifi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// commandTimeout 定义了 netsh 命令执行的默认超时时间。
+const commandTimeout = 10 * time.Second
+
+func init() {
+	registerProber(func() (Provider, error) {
+		return newNetshProvider()
+	})
+}
+
+// netshProvider 是基于Windows `netsh wlan` 命令的Provider实现。
+type netshProvider struct{}
+
+// newNetshProvider 探测 netsh 是否可用，可用则返回一个 netshProvider。
+func newNetshProvider() (Provider, error) {
+	if _, err := exec.LookPath("netsh"); err != nil {
+		return nil, fmt.Errorf("netsh: 未找到可执行文件: %w", err)
+	}
+	return &netshProvider{}, nil
+}
+
+// Name 实现 Provider。
+func (p *netshProvider) Name() string { return "netsh" }
+
+// Interfaces 实现 Provider。
+func (p *netshProvider) Interfaces() ([]string, error) {
+	stdout, _, err := runNetshCommand(commandTimeout, "wlan", "show", "interfaces")
+	if err != nil {
+		return nil, fmt.Errorf("执行 'netsh wlan show interfaces' 失败 (Interfaces): %w", err)
+	}
+	names := parseNetshInterfaceNames(stdout)
+	if len(names) == 0 {
+		return nil, errors.New("未能通过 'netsh wlan show interfaces' 检测到任何无线网络接口")
+	}
+	return names, nil
+}
+
+// Status 实现 Provider。
+func (p *netshProvider) Status(iface string) (InterfaceStatus, error) {
+	stdout, stderr, err := runNetshCommand(commandTimeout, "wlan", "show", "interfaces")
+	if err != nil {
+		errMsg := stderr
+		if errMsg == "" {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				errMsg = fmt.Sprintf("netsh 命令返回退出状态 %d (可能是WLAN AutoConfig服务未运行, 或Wi-Fi适配器被禁用/不存在)", exitErr.ExitCode())
+			}
+		}
+		return InterfaceStatus{}, fmt.Errorf("执行 'netsh wlan show interfaces' 失败 (Status): %w, stderr: %s", err, errMsg)
+	}
+	return parseNetshInterfaceStatus(stdout, iface), nil
+}
+
+// Scan 实现 Provider。
+func (p *netshProvider) Scan(iface string) ([]NetworkInfo, error) {
+	stdout, stderr, err := runNetshCommand(commandTimeout, "wlan", "show", "networks", fmt.Sprintf("interface=%q", iface), "mode=bssid")
+	if err != nil {
+		if strings.Contains(stderr, "没有无线网络可见") || strings.Contains(stderr, "No wireless networks are currently visible") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("执行 'netsh wlan show networks' 失败 (Scan): %w", err)
+	}
+	return parseNetshNetworks(stdout), nil
+}
+
+// Connect 实现 Provider。
+func (p *netshProvider) Connect(iface, ssid string, _ Credentials) error {
+	_, stderr, err := runNetshCommand(commandTimeout*2,
+		"wlan", "connect", fmt.Sprintf("name=%q", ssid), fmt.Sprintf("interface=%q", iface))
+	if err != nil {
+		return fmt.Errorf("netsh wlan connect 命令失败: %w, stderr: %s", err, stderr)
+	}
+	return nil
+}
+
+// Disconnect 实现 Provider。
+func (p *netshProvider) Disconnect(iface string) error {
+	_, stderr, err := runNetshCommand(commandTimeout, "wlan", "disconnect", fmt.Sprintf("interface=%q", iface))
+	if err != nil {
+		return fmt.Errorf("netsh wlan disconnect 命令失败: %w, stderr: %s", err, stderr)
+	}
+	return nil
+}
+
+// runNetshCommand 执行 netsh 命令并返回 stdout, stderr 和错误。
+// 它包含超时和隐藏窗口的逻辑。
+func runNetshCommand(timeout time.Duration, args ...string) (stdout string, stderr string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "netsh", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true} // 隐藏命令执行时弹出的控制台窗口
+
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+
+	err = cmd.Run()
+	stdout = outBuf.String()
+	stderr = errBuf.String()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return stdout, stderr, fmt.Errorf("命令 '%s' 执行超时 (%v)", strings.Join(args, " "), timeout)
+	}
+	if err != nil {
+		return stdout, stderr, fmt.Errorf("命令 '%s' 执行失败: %w, stderr: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr))
+	}
+	return stdout, stderr, nil
+}
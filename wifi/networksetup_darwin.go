@@ -0,0 +1,121 @@
+//go:build darwin
+
+package wifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// commandTimeout 定义了 networksetup/airport 命令执行的默认超时时间。
+const commandTimeout = 10 * time.Second
+
+// airportPath 是macOS自带的Wi-Fi扫描工具的固定路径。
+const airportPath = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
+
+func init() {
+	registerProber(func() (Provider, error) {
+		return newNetworksetupProvider()
+	})
+}
+
+// networksetupProvider 是基于macOS `networksetup`/`airport` 命令的Provider实现。
+type networksetupProvider struct{}
+
+// newNetworksetupProvider 探测 networksetup 是否可用，可用则返回一个 networksetupProvider。
+func newNetworksetupProvider() (Provider, error) {
+	if _, err := exec.LookPath("networksetup"); err != nil {
+		return nil, fmt.Errorf("networksetup: 未找到可执行文件: %w", err)
+	}
+	return &networksetupProvider{}, nil
+}
+
+// Name 实现 Provider。
+func (p *networksetupProvider) Name() string { return "networksetup" }
+
+// Interfaces 实现 Provider，通过 `networksetup -listallhardwareports` 找到 Wi-Fi 对应的设备名。
+func (p *networksetupProvider) Interfaces() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "networksetup", "-listallhardwareports").Output()
+	if err != nil {
+		return nil, fmt.Errorf("执行 'networksetup -listallhardwareports' 失败 (Interfaces): %w", err)
+	}
+	names := parseHardwarePorts(string(out))
+	if len(names) == 0 {
+		return nil, errors.New("未能通过 'networksetup -listallhardwareports' 检测到任何无线网络接口")
+	}
+	return names, nil
+}
+
+// Status 实现 Provider。
+func (p *networksetupProvider) Status(iface string) (InterfaceStatus, error) {
+	status := InterfaceStatus{Interface: iface, SignalPct: -1}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "networksetup", "-getairportnetwork", iface).Output()
+	if err != nil {
+		return status, fmt.Errorf("执行 'networksetup -getairportnetwork' 失败 (Status): %w", err)
+	}
+	ssid, connected := parseAirportNetwork(string(out))
+	status.Connected = connected
+	status.SSID = ssid
+
+	if connected {
+		ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+		defer cancel()
+		if scanOut, scanErr := exec.CommandContext(ctx, airportPath, "-I").Output(); scanErr == nil {
+			bssid, signal := parseAirportInfo(string(scanOut))
+			status.BSSID = bssid
+			status.SignalPct = signal
+		}
+	}
+	return status, nil
+}
+
+// Scan 实现 Provider。
+func (p *networksetupProvider) Scan(iface string) ([]NetworkInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, airportPath, "-s").Output()
+	if err != nil {
+		return nil, fmt.Errorf("执行 'airport -s' 失败 (Scan): %w", err)
+	}
+	return parseAirportScan(string(out)), nil
+}
+
+// Connect 实现 Provider。
+func (p *networksetupProvider) Connect(iface, ssid string, creds Credentials) error {
+	args := []string{"-setairportnetwork", iface, ssid}
+	if creds.Password != "" {
+		args = append(args, creds.Password)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	if _, err := exec.CommandContext(ctx, "networksetup", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("networksetup -setairportnetwork 命令失败: %w", err)
+	}
+	return nil
+}
+
+// Disconnect 实现 Provider。
+func (p *networksetupProvider) Disconnect(iface string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	if _, err := exec.CommandContext(ctx, "networksetup", "-setairportpower", iface, "off").CombinedOutput(); err != nil {
+		return fmt.Errorf("networksetup -setairportpower off 命令失败: %w", err)
+	}
+	ctx2, cancel2 := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel2()
+	if _, err := exec.CommandContext(ctx2, "networksetup", "-setairportpower", iface, "on").CombinedOutput(); err != nil {
+		return fmt.Errorf("networksetup -setairportpower on 命令失败: %w", err)
+	}
+	return nil
+}
+
+// parseHardwarePorts、parseAirportNetwork、parseAirportInfo、parseAirportScan 等
+// 纯解析函数定义在 networksetup_parse.go 中（不带构建标签，便于跨平台用golden输出测试）。
@@ -0,0 +1,93 @@
+package wifi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// macAddrPattern 匹配一个MAC地址（BSSID），用于在 `airport -s` 的输出中定位BSSID列，
+// 因为SSID列是左对齐的，SSID本身可以包含空格（如 "Home Guest WiFi"），
+// 不能简单地按 strings.Fields 切分。
+var macAddrPattern = regexp.MustCompile(`[0-9a-fA-F]{2}(?::[0-9a-fA-F]{2}){5}`)
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// parseHardwarePorts 从 `networksetup -listallhardwareports` 的输出中解析出
+// "Hardware Port: Wi-Fi" 对应的设备名称。
+func parseHardwarePorts(output string) []string {
+	var names []string
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "Hardware Port: Wi-Fi" {
+			continue
+		}
+		if i+1 >= len(lines) {
+			continue
+		}
+		deviceLine := strings.TrimSpace(lines[i+1])
+		if after, ok := strings.CutPrefix(deviceLine, "Device: "); ok {
+			names = append(names, after)
+		}
+	}
+	return names
+}
+
+// parseAirportNetwork 解析 `networksetup -getairportnetwork <iface>` 的输出。
+func parseAirportNetwork(output string) (ssid string, connected bool) {
+	line := strings.TrimSpace(output)
+	if after, ok := strings.CutPrefix(line, "Current Wi-Fi Network: "); ok {
+		return strings.TrimSpace(after), true
+	}
+	return "", false
+}
+
+// parseAirportInfo 解析 `airport -I` 的输出，返回 BSSID 和 RSSI 转换后的百分比。
+func parseAirportInfo(output string) (bssid string, signalPct int) {
+	signalPct = -1
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "BSSID:"):
+			bssid = strings.TrimSpace(strings.TrimPrefix(trimmed, "BSSID:"))
+		case strings.HasPrefix(trimmed, "agrCtlRSSI:"):
+			if rssi, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(trimmed, "agrCtlRSSI:"))); err == nil {
+				signalPct = DBmToPercent(rssi)
+			}
+		}
+	}
+	return bssid, signalPct
+}
+
+// parseAirportScan 解析 `airport -s` 的表格输出，列顺序为 "SSID BSSID RSSI CHANNEL ..."。
+// SSID列左对齐且可以包含空格（"Home Guest WiFi"之类在现实中很常见），不能按
+// strings.Fields 切分，否则SSID会被拆散、把BSSID和RSSI挤到错误的字段里。
+// 这里改为先用正则定位BSSID这个锚点：BSSID前的部分（去掉首尾空白）就是完整SSID，
+// BSSID之后紧跟的第一个字段就是RSSI。
+func parseAirportScan(output string) []NetworkInfo {
+	var networks []NetworkInfo
+	lines := strings.Split(output, "\n")
+	for _, line := range lines[min(1, len(lines)):] {
+		loc := macAddrPattern.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		ssid := strings.TrimSpace(line[:loc[0]])
+		bssid := line[loc[0]:loc[1]]
+		rest := strings.Fields(line[loc[1]:])
+		if ssid == "" || len(rest) == 0 {
+			continue
+		}
+		rssi, err := strconv.Atoi(rest[0])
+		if err != nil {
+			continue
+		}
+		networks = append(networks, NetworkInfo{
+			SSID:      ssid,
+			BSSID:     bssid,
+			SignalPct: DBmToPercent(rssi),
+		})
+	}
+	return networks
+}
@@ -0,0 +1,98 @@
+package wifi
+
+import "testing"
+
+// 以下golden输出摘自真实 `networksetup`/`airport` 命令的输出。
+
+const goldenHardwarePortsOutput = `Hardware Port: Wi-Fi
+Device: en0
+Ethernet Address: aa:bb:cc:dd:ee:ff
+
+Hardware Port: Bluetooth PAN
+Device: en1
+Ethernet Address: aa:bb:cc:dd:ee:00
+`
+
+const goldenAirportNetworkOutput = "Current Wi-Fi Network: HomeMain\n"
+
+const goldenAirportNetworkOffOutput = "You are not associated with an AirPort network.\n"
+
+const goldenAirportInfoOutput = `     agrCtlRSSI: -58
+     agrExtRSSI: 0
+    agrCtlNoise: -92
+    agrExtNoise: 0
+          state: running
+        op mode: station
+     lastTxRate: 400
+        maxRate: 866
+lastAssocStatus: 0
+    802.11 auth: open
+      link auth: wpa2-psk
+          BSSID: 11:22:33:44:55:66
+           SSID: HomeMain
+            MCS: 9
+        channel: 36,80
+`
+
+const goldenAirportScanOutput = `                            SSID BSSID             RSSI CHANNEL HT CC SECURITY (auth/unicast/group)
+                        HomeMain 11:22:33:44:55:66  -58  36,80   Y  US WPA2(PSK/AES/AES)
+                       HomeGuest 11:22:33:44:55:99  -71  6       Y  US NONE
+                 Home Guest WiFi 11:22:33:44:55:77  -71  6       Y  US NONE
+`
+
+func TestParseHardwarePorts(t *testing.T) {
+	names := parseHardwarePorts(goldenHardwarePortsOutput)
+	if len(names) != 1 || names[0] != "en0" {
+		t.Fatalf("parseHardwarePorts() = %v, want [en0]", names)
+	}
+}
+
+func TestParseAirportNetwork(t *testing.T) {
+	ssid, connected := parseAirportNetwork(goldenAirportNetworkOutput)
+	if !connected || ssid != "HomeMain" {
+		t.Fatalf("parseAirportNetwork() = (%q, %v), want (HomeMain, true)", ssid, connected)
+	}
+}
+
+func TestParseAirportNetworkDisconnected(t *testing.T) {
+	ssid, connected := parseAirportNetwork(goldenAirportNetworkOffOutput)
+	if connected || ssid != "" {
+		t.Fatalf("parseAirportNetwork() = (%q, %v), want (\"\", false)", ssid, connected)
+	}
+}
+
+func TestParseAirportInfo(t *testing.T) {
+	bssid, signalPct := parseAirportInfo(goldenAirportInfoOutput)
+	if bssid != "11:22:33:44:55:66" {
+		t.Fatalf("bssid = %q, want 11:22:33:44:55:66", bssid)
+	}
+	if want := DBmToPercent(-58); signalPct != want {
+		t.Fatalf("signalPct = %d, want %d", signalPct, want)
+	}
+}
+
+func TestParseAirportScan(t *testing.T) {
+	networks := parseAirportScan(goldenAirportScanOutput)
+	if len(networks) != 3 {
+		t.Fatalf("len(networks) = %d, want 3", len(networks))
+	}
+	if networks[0].SSID != "HomeMain" || networks[0].BSSID != "11:22:33:44:55:66" {
+		t.Fatalf("networks[0] = %+v, want SSID=HomeMain BSSID=11:22:33:44:55:66", networks[0])
+	}
+	if networks[1].SSID != "HomeGuest" || networks[1].BSSID != "11:22:33:44:55:99" {
+		t.Fatalf("networks[1] = %+v, want SSID=HomeGuest BSSID=11:22:33:44:55:99", networks[1])
+	}
+}
+
+func TestParseAirportScanSSIDWithSpaces(t *testing.T) {
+	networks := parseAirportScan(goldenAirportScanOutput)
+	if len(networks) != 3 {
+		t.Fatalf("len(networks) = %d, want 3", len(networks))
+	}
+	if networks[2].SSID != "Home Guest WiFi" || networks[2].BSSID != "11:22:33:44:55:77" {
+		t.Fatalf("networks[2] = %+v, want SSID=\"Home Guest WiFi\" BSSID=11:22:33:44:55:77", networks[2])
+	}
+	if want := DBmToPercent(-71); networks[2].SignalPct != want {
+		t.Fatalf("networks[2].SignalPct = %d, want %d", networks[2].SignalPct, want)
+	}
+}
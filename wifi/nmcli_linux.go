@@ -0,0 +1,104 @@
+//go:build linux
+
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commandTimeout 定义了 nmcli 命令执行的默认超时时间。
+const commandTimeout = 10 * time.Second
+
+func init() {
+	registerProber(func() (Provider, error) {
+		return newNmcliProvider()
+	})
+}
+
+// nmcliProvider 是基于Linux NetworkManager `nmcli` 命令的Provider实现。
+type nmcliProvider struct{}
+
+// newNmcliProvider 探测 nmcli 是否可用，可用则返回一个 nmcliProvider。
+func newNmcliProvider() (Provider, error) {
+	if _, err := exec.LookPath("nmcli"); err != nil {
+		return nil, fmt.Errorf("nmcli: 未找到可执行文件: %w", err)
+	}
+	return &nmcliProvider{}, nil
+}
+
+// Name 实现 Provider。
+func (p *nmcliProvider) Name() string { return "nmcli" }
+
+// Interfaces 实现 Provider，列出类型为 wifi 的设备。
+func (p *nmcliProvider) Interfaces() ([]string, error) {
+	stdout, err := runNmcli(commandTimeout, "-t", "-f", "DEVICE,TYPE", "device", "status")
+	if err != nil {
+		return nil, fmt.Errorf("执行 'nmcli device status' 失败 (Interfaces): %w", err)
+	}
+	names := parseNmcliWifiDevices(stdout)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("未能通过 'nmcli device status' 检测到任何无线网络接口")
+	}
+	return names, nil
+}
+
+// Status 实现 Provider。
+func (p *nmcliProvider) Status(iface string) (InterfaceStatus, error) {
+	stdout, err := runNmcli(commandTimeout, "-t", "-f", "ACTIVE,SSID,BSSID,SIGNAL", "device", "wifi", "list", "ifname", iface)
+	if err != nil {
+		return InterfaceStatus{}, fmt.Errorf("执行 'nmcli device wifi list' 失败 (Status): %w", err)
+	}
+	return parseNmcliActiveStatus(stdout, iface), nil
+}
+
+// Scan 实现 Provider。
+func (p *nmcliProvider) Scan(iface string) ([]NetworkInfo, error) {
+	stdout, err := runNmcli(commandTimeout, "-t", "-f", "SSID,BSSID,SIGNAL", "device", "wifi", "list", "ifname", iface, "--rescan", "yes")
+	if err != nil {
+		return nil, fmt.Errorf("执行 'nmcli device wifi list' 失败 (Scan): %w", err)
+	}
+	return parseNmcliNetworks(stdout), nil
+}
+
+// Connect 实现 Provider。
+func (p *nmcliProvider) Connect(iface, ssid string, creds Credentials) error {
+	args := []string{"device", "wifi", "connect", ssid, "ifname", iface}
+	if creds.Password != "" {
+		args = append(args, "password", creds.Password)
+	}
+	if _, err := runNmcli(commandTimeout*2, args...); err != nil {
+		return fmt.Errorf("nmcli device wifi connect 命令失败: %w", err)
+	}
+	return nil
+}
+
+// Disconnect 实现 Provider。
+func (p *nmcliProvider) Disconnect(iface string) error {
+	if _, err := runNmcli(commandTimeout, "device", "disconnect", iface); err != nil {
+		return fmt.Errorf("nmcli device disconnect 命令失败: %w", err)
+	}
+	return nil
+}
+
+// runNmcli 执行 nmcli 命令并返回 stdout。
+func runNmcli(timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "nmcli", args...)
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("命令 'nmcli %s' 执行超时 (%v)", strings.Join(args, " "), timeout)
+	}
+	if err != nil {
+		return string(out), fmt.Errorf("命令 'nmcli %s' 执行失败: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// parseNmcliWifiDevices、parseNmcliActiveStatus、parseNmcliNetworks 等纯解析函数
+// 定义在 nmcli_parse.go 中（不带构建标签，便于跨平台用golden输出测试）。
@@ -0,0 +1,87 @@
+package wifi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// splitNmcliTerseFields 按 `nmcli -t` terse模式的转义规则拆分一行输出为字段。
+// terse模式下字段内出现的字面 ":" 会被转义为 "\:"，字面 "\" 转义为 "\\"
+// （BSSID "AA:BB:CC:DD:EE:FF" 正是典型例子），必须先反转义再按未转义的 ":" 切分，
+// 否则会把一个BSSID错误拆成多个字段，并使后面的字段整体错位。
+func splitNmcliTerseFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case escaped:
+			current.WriteByte(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == ':':
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	fields = append(fields, current.String())
+	return fields
+}
+
+// parseNmcliWifiDevices 解析 `nmcli -t -f DEVICE,TYPE device status` 的输出，
+// 返回类型为 "wifi" 的设备名称列表。
+func parseNmcliWifiDevices(output string) []string {
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := splitNmcliTerseFields(line)
+		if len(fields) == 2 && fields[1] == "wifi" {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
+
+// parseNmcliActiveStatus 解析 `nmcli -t -f ACTIVE,SSID,BSSID,SIGNAL device wifi list` 的输出，
+// 返回当前已关联（ACTIVE 为 "yes"）的网络状态。
+func parseNmcliActiveStatus(output, iface string) InterfaceStatus {
+	status := InterfaceStatus{Interface: iface, SignalPct: -1}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := splitNmcliTerseFields(line)
+		if len(fields) < 4 || fields[0] != "yes" {
+			continue
+		}
+		status.Connected = true
+		status.SSID = fields[1]
+		status.BSSID = fields[2]
+		if n, err := strconv.Atoi(fields[3]); err == nil {
+			status.SignalPct = n
+		}
+		return status
+	}
+	return status
+}
+
+// parseNmcliNetworks 解析 `nmcli -t -f SSID,BSSID,SIGNAL device wifi list` 的输出。
+func parseNmcliNetworks(output string) []NetworkInfo {
+	var networks []NetworkInfo
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := splitNmcliTerseFields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		signal, _ := strconv.Atoi(fields[2])
+		networks = append(networks, NetworkInfo{
+			SSID:      fields[0],
+			BSSID:     fields[1],
+			SignalPct: signal,
+		})
+	}
+	return networks
+}
@@ -0,0 +1,75 @@
+package wifi
+
+import "testing"
+
+// 以下golden输出摘自真实 `nmcli -t` terse模式命令的输出。terse模式下字面 ":" 会被
+// 转义为 "\:"，BSSID正是最容易踩到这一点的字段。
+
+const goldenNmcliDeviceStatusOutput = `wlan0:wifi
+eth0:ethernet
+lo:loopback`
+
+const goldenNmcliActiveStatusOutput = `no:HomeGuest:AA\:BB\:CC\:DD\:EE\:01:54
+yes:HomeMain:AA\:BB\:CC\:DD\:EE\:FF:87`
+
+const goldenNmcliNetworksOutput = `HomeMain:AA\:BB\:CC\:DD\:EE\:FF:87
+HomeGuest:AA\:BB\:CC\:DD\:EE\:01:54`
+
+func TestSplitNmcliTerseFields(t *testing.T) {
+	fields := splitNmcliTerseFields(`yes:HomeMain:AA\:BB\:CC\:DD\:EE\:FF:87`)
+	want := []string{"yes", "HomeMain", "AA:BB:CC:DD:EE:FF", "87"}
+	if len(fields) != len(want) {
+		t.Fatalf("splitNmcliTerseFields() = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("fields[%d] = %q, want %q", i, fields[i], want[i])
+		}
+	}
+}
+
+func TestParseNmcliWifiDevices(t *testing.T) {
+	names := parseNmcliWifiDevices(goldenNmcliDeviceStatusOutput)
+	if len(names) != 1 || names[0] != "wlan0" {
+		t.Fatalf("parseNmcliWifiDevices() = %v, want [wlan0]", names)
+	}
+}
+
+func TestParseNmcliActiveStatus(t *testing.T) {
+	status := parseNmcliActiveStatus(goldenNmcliActiveStatusOutput, "wlan0")
+	if !status.Connected {
+		t.Fatalf("status.Connected = false, want true")
+	}
+	if status.SSID != "HomeMain" {
+		t.Fatalf("status.SSID = %q, want HomeMain", status.SSID)
+	}
+	if status.BSSID != "AA:BB:CC:DD:EE:FF" {
+		t.Fatalf("status.BSSID = %q, want AA:BB:CC:DD:EE:FF (un-escaped)", status.BSSID)
+	}
+	if status.SignalPct != 87 {
+		t.Fatalf("status.SignalPct = %d, want 87", status.SignalPct)
+	}
+}
+
+func TestParseNmcliActiveStatusUnconnected(t *testing.T) {
+	status := parseNmcliActiveStatus("no:HomeGuest:AA\\:BB\\:CC\\:DD\\:EE\\:01:54", "wlan0")
+	if status.Connected {
+		t.Fatalf("status.Connected = true, want false (no active network)")
+	}
+	if status.SignalPct != -1 {
+		t.Fatalf("status.SignalPct = %d, want -1", status.SignalPct)
+	}
+}
+
+func TestParseNmcliNetworks(t *testing.T) {
+	networks := parseNmcliNetworks(goldenNmcliNetworksOutput)
+	if len(networks) != 2 {
+		t.Fatalf("len(networks) = %d, want 2", len(networks))
+	}
+	if networks[0].SSID != "HomeMain" || networks[0].BSSID != "AA:BB:CC:DD:EE:FF" || networks[0].SignalPct != 87 {
+		t.Fatalf("networks[0] = %+v, want SSID=HomeMain BSSID=AA:BB:CC:DD:EE:FF SignalPct=87", networks[0])
+	}
+	if networks[1].SSID != "HomeGuest" || networks[1].BSSID != "AA:BB:CC:DD:EE:01" || networks[1].SignalPct != 54 {
+		t.Fatalf("networks[1] = %+v, want SSID=HomeGuest BSSID=AA:BB:CC:DD:EE:01 SignalPct=54", networks[1])
+	}
+}
@@ -0,0 +1,78 @@
+// Package wifi 定义了跨平台WiFi操作的抽象接口，
+// 并通过构建标签为每个操作系统选择具体的实现（netsh、nmcli/iw、networksetup等）。
+package wifi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InterfaceStatus 描述一个无线网络接口当前的关联状态。
+type InterfaceStatus struct {
+	Interface string // 接口名称，例如 "Wi-Fi" 或 "wlan0"
+	Connected bool   // 是否已关联到某个网络
+	SSID      string // 当前关联的SSID，未连接时为空
+	BSSID     string // 当前关联的BSSID，未连接时为空
+	SignalPct int    // 信号强度百分比 (0-100)，未知时为 -1
+}
+
+// NetworkInfo 描述一次扫描中发现的单个无线网络。
+type NetworkInfo struct {
+	SSID      string
+	BSSID     string
+	SignalPct int // 信号强度百分比 (0-100)
+}
+
+// Credentials 携带连接某个SSID所需的凭据。
+// nmcli、networksetup、iw/wpa_cli 后端在 Password 非空时使用它；
+// netsh 后端忽略 Password，总是按与SSID同名的已有Profile连接。
+type Credentials struct {
+	Password string
+}
+
+// ErrNoProvider 表示在当前平台上没有可用的WiFi后端。
+var ErrNoProvider = errors.New("wifi: 当前平台没有可用的WiFi后端")
+
+// Provider 是具体WiFi后端（netsh、nmcli、networksetup等）必须实现的接口。
+// 所有方法都应在合理的时间内返回，调用方负责在需要时施加超时控制。
+type Provider interface {
+	// Name 返回该后端的标识，用于日志记录，例如 "netsh"、"nmcli"。
+	Name() string
+	// Interfaces 列出系统上可用的无线网络接口名称。
+	Interfaces() ([]string, error)
+	// Scan 触发（或读取缓存的）扫描并返回指定接口上可见的网络列表。
+	Scan(iface string) ([]NetworkInfo, error)
+	// Status 返回指定接口当前的关联状态。
+	Status(iface string) (InterfaceStatus, error)
+	// Connect 尝试让指定接口连接到目标SSID。
+	Connect(iface, ssid string, creds Credentials) error
+	// Disconnect 断开指定接口当前的连接。
+	Disconnect(iface string) error
+}
+
+// probers 按平台注册可能可用的Provider构造函数，由各平台特定文件通过 init() 填充。
+var probers []func() (Provider, error)
+
+// registerProber 供各平台实现在 init() 中注册自己。
+func registerProber(p func() (Provider, error)) {
+	probers = append(probers, p)
+}
+
+// AutoProbe 依次尝试当前平台上已注册的后端，返回第一个探测成功的Provider。
+// 这类似于网络设备驱动里常见的 AutoProbe 模式：按优先级尝试每个候选后端，
+// 第一个声明自己可用的胜出。
+func AutoProbe() (Provider, error) {
+	var errs []error
+	for _, newProvider := range probers {
+		p, err := newProvider()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return p, nil
+	}
+	if len(errs) == 0 {
+		return nil, ErrNoProvider
+	}
+	return nil, fmt.Errorf("%w: %w", ErrNoProvider, errors.Join(errs...))
+}
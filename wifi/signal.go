@@ -0,0 +1,28 @@
+package wifi
+
+// DBmToPercent 和 PercentToDBm 实现了与Windows WLAN API一致的线性映射
+// (-100dBm ≈ 0%, -50dBm ≈ 100%)，供那些只报告百分比（如netsh）或
+// 只报告dBm（如macOS airport）的后端互相换算，从而让上层可以统一按dBm阈值比较信号强度。
+
+// DBmToPercent 将dBm信号强度转换为0-100的百分比。
+func DBmToPercent(dbm int) int {
+	switch {
+	case dbm <= -100:
+		return 0
+	case dbm >= -50:
+		return 100
+	default:
+		return 2 * (dbm + 100)
+	}
+}
+
+// PercentToDBm 是 DBmToPercent 的反函数，输入非法值 (<0) 时返回 -100。
+func PercentToDBm(pct int) int {
+	if pct < 0 {
+		return -100
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	return pct/2 - 100
+}
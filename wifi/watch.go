@@ -0,0 +1,52 @@
+package wifi
+
+import "time"
+
+// EventType 标识一次网络状态变化通知的类别。
+type EventType int
+
+const (
+	// EventDisconnected 对应 wlan_notification_acm_disconnected / 链路丢失通知。
+	EventDisconnected EventType = iota
+	// EventConnectionComplete 对应 wlan_notification_acm_connection_complete / 链路建立通知。
+	EventConnectionComplete
+	// EventScanComplete 对应 wlan_notification_acm_scan_complete / 扫描完成通知。
+	EventScanComplete
+)
+
+// String 实现 fmt.Stringer，便于日志输出。
+func (t EventType) String() string {
+	switch t {
+	case EventDisconnected:
+		return "disconnected"
+	case EventConnectionComplete:
+		return "connection_complete"
+	case EventScanComplete:
+		return "scan_complete"
+	default:
+		return "unknown"
+	}
+}
+
+// Event 是一次操作系统网络状态变化通知，经过解析后的类型化表示。
+type Event struct {
+	Type      EventType
+	Interface string
+	Time      time.Time
+}
+
+// Watcher 持续监听某个接口上的操作系统网络状态变化通知。
+// 实现者负责将底层回调/套接字事件翻译成 Event 并投递到 Events() 返回的通道，
+// 通道应在 Close 后被关闭。
+type Watcher interface {
+	Events() <-chan Event
+	Close() error
+}
+
+// NotificationSource 是Provider的一个可选扩展接口：
+// 支持事件驱动通知的后端（Windows WlanRegisterNotification、Linux netlink、
+// BSD/macOS 路由套接字）应实现它。不支持的后端可以省略，
+// 调用方此时应退化为纯定时轮询。
+type NotificationSource interface {
+	Watch(iface string) (Watcher, error)
+}
@@ -0,0 +1,89 @@
+//go:build darwin
+
+package wifi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Watch 实现 NotificationSource，通过一个 AF_ROUTE 套接字监听
+// RTM_IFINFO 路由消息，从而在没有NetworkManager/wlanapi等推送机制的BSD系内核上
+// 感知接口状态变化，这与BSD `route` 工具依赖的机制相同。
+func (p *networksetupProvider) Watch(iface string) (Watcher, error) {
+	fd, err := unix.Socket(unix.AF_ROUTE, unix.SOCK_RAW, 0)
+	if err != nil {
+		return nil, fmt.Errorf("创建 AF_ROUTE 套接字失败: %w", err)
+	}
+
+	w := &darwinWatcher{
+		fd:     fd,
+		iface:  iface,
+		events: make(chan Event, 16),
+		done:   make(chan struct{}),
+	}
+	go w.loop()
+	return w, nil
+}
+
+// darwinWatcher 是基于 AF_ROUTE 路由套接字的 Watcher 实现。
+type darwinWatcher struct {
+	fd     int
+	iface  string
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events 实现 Watcher。
+func (w *darwinWatcher) Events() <-chan Event { return w.events }
+
+// Close 实现 Watcher。
+func (w *darwinWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = unix.Close(w.fd)
+		close(w.events)
+	})
+	return err
+}
+
+// loop 读取路由套接字上的消息，运行在独立goroutine中直到 Close 被调用。
+func (w *darwinWatcher) loop() {
+	buf := make([]byte, 2048)
+	for {
+		n, err := unix.Read(w.fd, buf)
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		if err != nil {
+			return
+		}
+		if n < 4 {
+			continue
+		}
+		w.handleMessage(buf[:n])
+	}
+}
+
+// handleMessage 解析一条路由消息的类型字段（rtm_type，偏移量3）并翻译为 wifi.Event。
+// RTM_IFINFO (0x0e) 表示接口状态发生了变化。
+func (w *darwinWatcher) handleMessage(b []byte) {
+	const rtmIfInfo = 0x0e
+	msgType := b[3]
+	if msgType != rtmIfInfo {
+		return
+	}
+
+	evt := Event{Type: EventConnectionComplete, Interface: w.iface, Time: time.Now()}
+	select {
+	case w.events <- evt:
+	default:
+	}
+}
@@ -0,0 +1,105 @@
+//go:build linux
+
+package wifi
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Watch 实现 NotificationSource，通过一个绑定到 RTMGRP_LINK 组播组的
+// netlink(NETLINK_ROUTE) 套接字监听链路状态变化，并翻译为 wifi.Event。
+// nmcli 本身不提供推送式通知，因此事件来自内核的链路状态而非 NetworkManager。
+func (p *nmcliProvider) Watch(iface string) (Watcher, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, fmt.Errorf("创建 netlink 套接字失败: %w", err)
+	}
+
+	addr := &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: unix.RTMGRP_LINK}
+	if err := unix.Bind(fd, addr); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("绑定 netlink 套接字失败: %w", err)
+	}
+
+	w := &linuxWatcher{
+		fd:     fd,
+		iface:  iface,
+		events: make(chan Event, 16),
+		done:   make(chan struct{}),
+	}
+	go w.loop()
+	return w, nil
+}
+
+// linuxWatcher 是基于 NETLINK_ROUTE 套接字的 Watcher 实现。
+type linuxWatcher struct {
+	fd     int
+	iface  string
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events 实现 Watcher。
+func (w *linuxWatcher) Events() <-chan Event { return w.events }
+
+// Close 实现 Watcher。
+func (w *linuxWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = unix.Close(w.fd)
+		close(w.events)
+	})
+	return err
+}
+
+// loop 读取并解析 netlink 消息，运行在独立goroutine中直到 Close 被调用。
+func (w *linuxWatcher) loop() {
+	buf := make([]byte, 4096)
+	for {
+		n, _, err := unix.Recvfrom(w.fd, buf, 0)
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		if err != nil {
+			return
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, msg := range msgs {
+			w.handleMessage(msg)
+		}
+	}
+}
+
+// handleMessage 将一条 RTM_NEWLINK/RTM_DELLINK 消息翻译为 wifi.Event。
+// RTM_NEWLINK 记为 EventConnectionComplete，RTM_DELLINK 记为 EventDisconnected。
+// 本包不细分具体是哪个网卡触发，事件的 Interface 总是被监听的接口，
+// 交由上层的 Status 调用去核实真实状态（避免在这里做过度解析）。
+func (w *linuxWatcher) handleMessage(msg syscall.NetlinkMessage) {
+	var eventType EventType
+	switch msg.Header.Type {
+	case unix.RTM_NEWLINK:
+		eventType = EventConnectionComplete
+	case unix.RTM_DELLINK:
+		eventType = EventDisconnected
+	default:
+		return
+	}
+
+	evt := Event{Type: eventType, Interface: w.iface, Time: time.Now()}
+	select {
+	case w.events <- evt:
+	default:
+	}
+}
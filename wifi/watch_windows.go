@@ -0,0 +1,125 @@
+//go:build windows
+
+package wifi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// 以下常量取自 wlanapi.h，仅保留本包用到的子集。
+const (
+	wlanNotificationSourceACM = 0x00000008
+
+	wlanNotificationACMConnectionComplete = 10
+	wlanNotificationACMDisconnected       = 21
+	wlanNotificationACMScanComplete       = 7
+)
+
+// wlanNotificationData 对应 WLAN_NOTIFICATION_DATA 结构体中本包关心的前缀字段。
+// 完整结构体包含一个跟随在头部之后的变长数据段，这里只解析固定头部即可判断事件类型和接口。
+type wlanNotificationData struct {
+	NotificationSource uint32
+	NotificationCode   uint32
+	InterfaceGuid      windows.GUID
+	DataSize           uint32
+	Data               uintptr
+}
+
+var (
+	wlanapi               = windows.NewLazySystemDLL("wlanapi.dll")
+	procWlanOpenHandle    = wlanapi.NewProc("WlanOpenHandle")
+	procWlanCloseHandle   = wlanapi.NewProc("WlanCloseHandle")
+	procWlanRegisterNotif = wlanapi.NewProc("WlanRegisterNotification")
+)
+
+// Watch 实现 NotificationSource，通过 WlanRegisterNotification 订阅
+// ACM（Auto Configuration Module）通知，并将其翻译为 wifi.Event 推送到返回的通道。
+func (p *netshProvider) Watch(iface string) (Watcher, error) {
+	var handle windows.Handle
+	var negotiatedVersion uint32
+	ret, _, _ := procWlanOpenHandle.Call(2, 0, uintptr(unsafe.Pointer(&negotiatedVersion)), uintptr(unsafe.Pointer(&handle)))
+	if ret != 0 {
+		return nil, fmt.Errorf("WlanOpenHandle 失败, 错误码: %d", ret)
+	}
+
+	w := &windowsWatcher{
+		handle: handle,
+		iface:  iface,
+		events: make(chan Event, 16),
+	}
+
+	callback := windows.NewCallback(w.onNotification)
+	ret, _, _ = procWlanRegisterNotif.Call(
+		uintptr(handle),
+		wlanNotificationSourceACM,
+		0,
+		callback,
+		0,
+		0,
+		0,
+	)
+	if ret != 0 {
+		procWlanCloseHandle.Call(uintptr(handle), 0)
+		return nil, fmt.Errorf("WlanRegisterNotification 失败, 错误码: %d", ret)
+	}
+	return w, nil
+}
+
+// windowsWatcher 是基于 wlanapi.dll 通知回调的 Watcher 实现。
+type windowsWatcher struct {
+	handle windows.Handle
+	iface  string
+	events chan Event
+	once   sync.Once
+}
+
+// Events 实现 Watcher。
+func (w *windowsWatcher) Events() <-chan Event { return w.events }
+
+// Close 实现 Watcher，注销通知并关闭WLAN句柄。
+func (w *windowsWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		// 传入空回调以取消注册（WlanRegisterNotification 的惯用做法）。
+		procWlanRegisterNotif.Call(uintptr(w.handle), wlanNotificationSourceACM, 1, 0, 0, 0, 0)
+		ret, _, _ := procWlanCloseHandle.Call(uintptr(w.handle), 0)
+		if ret != 0 {
+			err = fmt.Errorf("WlanCloseHandle 失败, 错误码: %d", ret)
+		}
+		close(w.events)
+	})
+	return err
+}
+
+// onNotification 是传给 WlanRegisterNotification 的回调函数，运行在系统回调线程上，
+// 因此仅做最小化的解析工作并立即把结果投递到带缓冲的 events 通道，避免阻塞系统线程。
+func (w *windowsWatcher) onNotification(data *wlanNotificationData, _ uintptr) uintptr {
+	if data == nil || data.NotificationSource != wlanNotificationSourceACM {
+		return 0
+	}
+
+	var eventType EventType
+	switch data.NotificationCode {
+	case wlanNotificationACMDisconnected:
+		eventType = EventDisconnected
+	case wlanNotificationACMConnectionComplete:
+		eventType = EventConnectionComplete
+	case wlanNotificationACMScanComplete:
+		eventType = EventScanComplete
+	default:
+		return 0 // 忽略本包不关心的通知代码
+	}
+
+	evt := Event{Type: eventType, Interface: w.iface, Time: time.Now()}
+	select {
+	case w.events <- evt:
+	default:
+		// 通道已满，丢弃该事件而不是阻塞回调线程；下一次轮询回退会兜底。
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+//go:build linux
+
+package wifi
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+func init() {
+	registerProber(func() (Provider, error) {
+		return newWpaProvider()
+	})
+}
+
+// wpaProvider 是基于 `iw`（扫描/状态查询）与 `wpa_cli`（控制 wpa_supplicant 连接/断开）
+// 的Provider实现，用于没有安装NetworkManager/nmcli的Linux主机（精简发行版、嵌入式设备、
+// 仅跑裸 wpa_supplicant 的场景）。在 AutoProbe 的顺序中排在 nmcli 之后，
+// 作为它不可用时的后备选择。
+type wpaProvider struct{}
+
+// newWpaProvider 探测 iw 和 wpa_cli 是否都可用，都可用才返回一个 wpaProvider。
+func newWpaProvider() (Provider, error) {
+	if _, err := exec.LookPath("iw"); err != nil {
+		return nil, fmt.Errorf("iw: 未找到可执行文件: %w", err)
+	}
+	if _, err := exec.LookPath("wpa_cli"); err != nil {
+		return nil, fmt.Errorf("wpa_cli: 未找到可执行文件: %w", err)
+	}
+	return &wpaProvider{}, nil
+}
+
+// Name 实现 Provider。
+func (p *wpaProvider) Name() string { return "iw/wpa_cli" }
+
+// Interfaces 实现 Provider，列出 `iw dev` 报告的所有无线接口。
+func (p *wpaProvider) Interfaces() ([]string, error) {
+	stdout, err := runIw(commandTimeout, "dev")
+	if err != nil {
+		return nil, fmt.Errorf("执行 'iw dev' 失败 (Interfaces): %w", err)
+	}
+	names := parseIwDevInterfaces(stdout)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("未能通过 'iw dev' 检测到任何无线网络接口")
+	}
+	return names, nil
+}
+
+// Status 实现 Provider。
+func (p *wpaProvider) Status(iface string) (InterfaceStatus, error) {
+	stdout, err := runIw(commandTimeout, "dev", iface, "link")
+	if err != nil {
+		return InterfaceStatus{}, fmt.Errorf("执行 'iw dev link' 失败 (Status): %w", err)
+	}
+	status := parseIwLink(stdout)
+	status.Interface = iface
+	return status, nil
+}
+
+// Scan 实现 Provider。
+func (p *wpaProvider) Scan(iface string) ([]NetworkInfo, error) {
+	stdout, err := runIw(commandTimeout, "dev", iface, "scan")
+	if err != nil {
+		return nil, fmt.Errorf("执行 'iw dev scan' 失败 (Scan): %w", err)
+	}
+	return parseIwScan(stdout), nil
+}
+
+// Connect 实现 Provider：通过 wpa_cli 新增一个网络配置并选中它，让wpa_supplicant
+// 接管实际的关联过程。DHCP仍由系统上运行的dhclient/dhcpcd等守护进程负责，
+// 不在这里处理，与 netsh/nmcli/networksetup 三个后端保持一致——它们也只负责
+// 触发关联，真正的DHCP完成情况由 health.go 的 checkDHCP 去验证。
+func (p *wpaProvider) Connect(iface, ssid string, creds Credentials) error {
+	if err := removeExistingNetwork(iface, ssid); err != nil {
+		return fmt.Errorf("清理 %s 的旧网络配置失败: %w", ssid, err)
+	}
+
+	netID, err := runWpaCli(commandTimeout, iface, "add_network")
+	if err != nil {
+		return fmt.Errorf("wpa_cli add_network 命令失败: %w", err)
+	}
+	netID = strings.TrimSpace(netID)
+
+	if _, err := runWpaCli(commandTimeout, iface, "set_network", netID, "ssid", quoteWpaCliString(ssid)); err != nil {
+		_, _ = runWpaCli(commandTimeout, iface, "remove_network", netID)
+		return fmt.Errorf("wpa_cli set_network ssid 命令失败: %w", err)
+	}
+	if creds.Password != "" {
+		if _, err := runWpaCli(commandTimeout, iface, "set_network", netID, "psk", quoteWpaCliString(creds.Password)); err != nil {
+			_, _ = runWpaCli(commandTimeout, iface, "remove_network", netID)
+			return fmt.Errorf("wpa_cli set_network psk 命令失败: %w", err)
+		}
+	} else {
+		if _, err := runWpaCli(commandTimeout, iface, "set_network", netID, "key_mgmt", "NONE"); err != nil {
+			_, _ = runWpaCli(commandTimeout, iface, "remove_network", netID)
+			return fmt.Errorf("wpa_cli set_network key_mgmt 命令失败: %w", err)
+		}
+	}
+	if _, err := runWpaCli(commandTimeout, iface, "enable_network", netID); err != nil {
+		_, _ = runWpaCli(commandTimeout, iface, "remove_network", netID)
+		return fmt.Errorf("wpa_cli enable_network 命令失败: %w", err)
+	}
+	if _, err := runWpaCli(commandTimeout*2, iface, "select_network", netID); err != nil {
+		_, _ = runWpaCli(commandTimeout, iface, "remove_network", netID)
+		return fmt.Errorf("wpa_cli select_network 命令失败: %w", err)
+	}
+	return nil
+}
+
+// Disconnect 实现 Provider。
+func (p *wpaProvider) Disconnect(iface string) error {
+	if _, err := runWpaCli(commandTimeout, iface, "disconnect"); err != nil {
+		return fmt.Errorf("wpa_cli disconnect 命令失败: %w", err)
+	}
+	return nil
+}
+
+// removeExistingNetwork 删除 wpa_supplicant 中已存在的、SSID与目标相同的网络配置项（如果有）。
+// Connect 每次都通过 add_network 创建新条目，如果不先清理同SSID的旧条目，
+// 这个本来就长期运行的守护进程每次重连/漫游都会往 wpa_supplicant 的网络列表
+// （以及 update_config=1 时对应的磁盘配置文件）里追加一条，条目数量会无限增长。
+func removeExistingNetwork(iface, ssid string) error {
+	stdout, err := runWpaCli(commandTimeout, iface, "list_networks")
+	if err != nil {
+		return fmt.Errorf("wpa_cli list_networks 命令失败: %w", err)
+	}
+	for _, id := range parseWpaCliNetworkIDs(stdout, ssid) {
+		if _, err := runWpaCli(commandTimeout, iface, "remove_network", id); err != nil {
+			return fmt.Errorf("wpa_cli remove_network %s 命令失败: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// runIw 执行 iw 命令并返回 stdout。
+func runIw(timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "iw", args...)
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("命令 'iw %s' 执行超时 (%v)", strings.Join(args, " "), timeout)
+	}
+	if err != nil {
+		return string(out), fmt.Errorf("命令 'iw %s' 执行失败: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// runWpaCli 对指定接口执行 wpa_cli 命令并返回 stdout。
+func runWpaCli(timeout time.Duration, iface string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fullArgs := append([]string{"-i", iface}, args...)
+	cmd := exec.CommandContext(ctx, "wpa_cli", fullArgs...)
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("命令 'wpa_cli %s' 执行超时 (%v)", strings.Join(fullArgs, " "), timeout)
+	}
+	if err != nil {
+		return string(out), fmt.Errorf("命令 'wpa_cli %s' 执行失败: %w", strings.Join(fullArgs, " "), err)
+	}
+	if trimmed := strings.TrimSpace(string(out)); trimmed == "FAIL" {
+		return "", fmt.Errorf("命令 'wpa_cli %s' 返回 FAIL", strings.Join(fullArgs, " "))
+	}
+	return string(out), nil
+}
+
+// parseIwDevInterfaces、parseIwLink、parseIwScan 等纯解析函数定义在 iw_parse.go 中，
+// parseWpaCliNetworkIDs、quoteWpaCliString 定义在 wpa_parse.go 中
+// （均不带构建标签，便于跨平台用golden输出测试）。
@@ -0,0 +1,33 @@
+package wifi
+
+import "strings"
+
+// 以下解析函数是纯字符串处理逻辑，不依赖任何系统调用，
+// 因此可以在任意平台上用录制好的命令输出（golden captures）进行单元测试。
+
+// parseWpaCliNetworkIDs 从 `wpa_cli list_networks` 的输出中找出所有SSID与 targetSSID
+// 相同的网络配置项，返回它们的 network id。list_networks 的输出是以Tab分隔的表格：
+// "network id / ssid / bssid / flags"，首行为表头。
+func parseWpaCliNetworkIDs(output, targetSSID string) []string {
+	var ids []string
+	lines := strings.Split(output, "\n")
+	for _, line := range lines[min(1, len(lines)):] {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		if fields[1] != targetSSID {
+			continue
+		}
+		ids = append(ids, strings.TrimSpace(fields[0]))
+	}
+	return ids
+}
+
+// quoteWpaCliString 给 wpa_cli set_network 的字符串参数（ssid/psk）加上wpa_supplicant
+// 要求的外层双引号，并转义字符串内部出现的反斜杠和双引号，避免SSID/密码中包含
+// 这两个字符时提前终止引号、导致静默截断成另一个（错误的）网络名/密码。
+func quoteWpaCliString(s string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+	return `"` + escaped + `"`
+}
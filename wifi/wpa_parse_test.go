@@ -0,0 +1,30 @@
+package wifi
+
+import "testing"
+
+const goldenWpaCliListNetworksOutput = "network id\tssid\tbssid\tflags\n" +
+	"0\tHomeMain\tany\t[CURRENT]\n" +
+	"1\tHomeGuest\tany\t[DISABLED]\n" +
+	"2\tHomeMain\tany\t[DISABLED]\n"
+
+func TestParseWpaCliNetworkIDs(t *testing.T) {
+	ids := parseWpaCliNetworkIDs(goldenWpaCliListNetworksOutput, "HomeMain")
+	if len(ids) != 2 || ids[0] != "0" || ids[1] != "2" {
+		t.Fatalf("parseWpaCliNetworkIDs() = %v, want [0 2]", ids)
+	}
+}
+
+func TestParseWpaCliNetworkIDsNoMatch(t *testing.T) {
+	ids := parseWpaCliNetworkIDs(goldenWpaCliListNetworksOutput, "Unknown")
+	if len(ids) != 0 {
+		t.Fatalf("parseWpaCliNetworkIDs() = %v, want empty", ids)
+	}
+}
+
+func TestQuoteWpaCliString(t *testing.T) {
+	got := quoteWpaCliString(`My"Net\Weird`)
+	want := `"My\"Net\\Weird"`
+	if got != want {
+		t.Fatalf("quoteWpaCliString() = %q, want %q", got, want)
+	}
+}